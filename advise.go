@@ -0,0 +1,80 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"fmt"
+	"math"
+)
+
+// ErrTooStrong is returned by Advise when no phrase of MaxWords words is
+// strong enough.
+var ErrTooStrong = fmt.Errorf("no phrase of %d words is strong enough", MaxWords)
+
+// Advice is what a password needs to hold out against an attacker.
+type Advice struct {
+	Attacker Attacker
+	// Seconds is how long the attacker should take on average.
+	Seconds float64
+	// Bits is the least strength that takes that long.
+	Bits float64
+	// Phrases are the shortest phrase from each list with Bits. NumWords is
+	// 0 for a list that needs more than MaxWords.
+	Phrases []PhraseAdvice
+	// Apple is the Apple style password with Bits, three groups if they are
+	// enough, else four. Long tells which, and Bits is 0 if neither is.
+	Apple PhraseAdvice
+}
+
+// PhraseAdvice is a kind of password and how strong and long it is.
+type PhraseAdvice struct {
+	Dict     Dictionary
+	NumWords int
+	Long     bool
+	Bits     float64
+	// Length is the typical length in characters, spaces included.
+	Length int
+}
+
+// Advise works out the least words from each list, and the Apple style
+// password, that take attacker seconds on average to guess.
+func Advise(attacker Attacker, seconds float64) (Advice, error) {
+	a := Advice{Attacker: attacker, Seconds: seconds, Bits: BitsToResist(seconds, attacker)}
+	if !(seconds > 0) || math.IsInf(a.Bits, 0) || math.IsNaN(a.Bits) {
+		return a, fmt.Errorf("can't advise for %v seconds against %s", seconds, attacker.Name)
+	}
+	if a.Bits > EstimateBits(MaxWords, Large) {
+		return a, ErrTooStrong
+	}
+	for _, dict := range []Dictionary{Large, Short, Short2} {
+		n := 1
+		for EstimateBits(n, dict) < a.Bits {
+			n++
+		}
+		if n > MaxWords {
+			a.Phrases = append(a.Phrases, PhraseAdvice{Dict: dict})
+			continue
+		}
+		a.Phrases = append(a.Phrases, PhraseAdvice{
+			Dict:     dict,
+			NumWords: n,
+			Bits:     EstimateBits(n, dict),
+			Length:   int(math.Round(float64(n)*meanWordLength(dict))) + n - 1,
+		})
+	}
+	switch {
+	case appleBits >= a.Bits:
+		a.Apple = PhraseAdvice{Bits: appleBits, Length: appleLength}
+	case appleLongBits >= a.Bits:
+		a.Apple = PhraseAdvice{Long: true, Bits: appleLongBits, Length: appleLongLength}
+	}
+	return a, nil
+}
+
+func meanWordLength(dict Dictionary) float64 {
+	words := List(dict).Words()
+	total := 0
+	for _, w := range words {
+		total += len(w)
+	}
+	return float64(total) / float64(len(words))
+}
@@ -0,0 +1,59 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestAdvise(t *testing.T) {
+	ntlm, ok := FindAttacker(DefaultAttackers(), "ntlm")
+	if !ok {
+		t.Fatal("no ntlm attacker")
+	}
+	a, err := Advise(ntlm, 100*SecondsPerYear)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.Bits < 73 || a.Bits > 74 {
+		t.Errorf("unexpected bits %v", a.Bits)
+	}
+	want := map[Dictionary]int{Large: 6, Short: 8, Short2: 8}
+	for _, p := range a.Phrases {
+		if p.NumWords != want[p.Dict] || p.Bits < a.Bits || EstimateBits(p.NumWords-1, p.Dict) >= a.Bits {
+			t.Errorf("unexpected advice %+v", p)
+		}
+		if p.Length < p.NumWords*4 || p.Length > p.NumWords*10 {
+			t.Errorf("unexpected length %+v", p)
+		}
+	}
+	if a.Apple.Long || a.Apple.Bits != 87 || a.Apple.Length != 20 {
+		t.Errorf("unexpected apple advice %+v", a.Apple)
+	}
+
+	if a, _ := Advise(ntlm, 1e12*SecondsPerYear); !a.Apple.Long {
+		t.Errorf("expected long apple advice, got %+v", a.Apple)
+	}
+	if a, _ := Advise(ntlm, 1e25*SecondsPerYear); a.Apple.Bits != 0 {
+		t.Errorf("expected no apple advice, got %+v", a.Apple)
+	}
+
+	// 230 bits is under 20 large words but over 20 short ones
+	a, err = Advise(ntlm, math.Exp2(229)/ntlm.Rate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.Phrases[0].NumWords != 18 || a.Phrases[1].NumWords != 0 || a.Phrases[2].NumWords != 0 {
+		t.Errorf("unexpected advice %+v", a.Phrases)
+	}
+
+	if _, err := Advise(ntlm, 1e300*SecondsPerYear); !errors.Is(err, ErrTooStrong) {
+		t.Errorf("expected ErrTooStrong, got %v", err)
+	}
+	for _, seconds := range []float64{0, -1, math.Inf(1), math.NaN()} {
+		if _, err := Advise(ntlm, seconds); err == nil {
+			t.Errorf("%v seconds: expected error, got none", seconds)
+		}
+	}
+}
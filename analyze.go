@@ -0,0 +1,241 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ListAnalysis is what AnalyzeWords found in a word list.
+type ListAnalysis struct {
+	Words int
+	// BitsPerWord is the entropy of a word picked uniformly from the rows,
+	// which is less than log2(Words) if any word repeats.
+	BitsPerWord float64
+	// Duplicates are words on more than one row, ignoring case.
+	Duplicates []string
+	// PrefixPairs are pairs of words where the first starts the second.
+	// Phrases typed without separators can be ambiguous if there are any.
+	PrefixPairs [][2]string
+	// UniquePrefix is the fewest letters that tell every word apart, or 0
+	// if even whole words don't.
+	UniquePrefix int
+	// MinDistance is the smallest edit distance between two words, and
+	// ClosePairs the pairs that far apart. One typo can turn one into
+	// the other.
+	MinDistance int
+	ClosePairs  [][2]string
+	// Lengths counts the words of each length in letters.
+	Lengths    map[int]int
+	MinLength  int
+	MaxLength  int
+	MeanLength float64
+	// NonASCII are the words with characters outside ASCII, which are
+	// hard to type on some keyboards and can be written more than one way.
+	NonASCII []string
+	// SimilarSpellings are groups of words spelled alike once spellings
+	// that can make the same sound are folded together, such as knight and
+	// night, or acid and aside. Some sound alike, so a phrase read aloud
+	// can be misheard; many don't, so they need checking by ear.
+	SimilarSpellings [][]string
+}
+
+// AnalyzeWords checks a candidate word list for the problems listed in
+// ListAnalysis. It works on any words, so a list can be checked before it
+// is good enough for ParseWordList.
+func AnalyzeWords(words []string) ListAnalysis {
+	a := ListAnalysis{Words: len(words), Lengths: make(map[int]int)}
+	if len(words) == 0 {
+		return a
+	}
+
+	counts := make(map[string]int, len(words))
+	var unique []string
+	for _, w := range words {
+		key := strings.ToLower(w)
+		if counts[key] == 0 {
+			unique = append(unique, key)
+		}
+		counts[key]++
+		if counts[key] == 2 {
+			a.Duplicates = append(a.Duplicates, key)
+		}
+	}
+	for _, c := range counts {
+		p := float64(c) / float64(len(words))
+		a.BitsPerWord -= p * math.Log2(p)
+	}
+	sort.Strings(unique)
+
+	// in sorted order every word that starts with w comes right after it
+	for i, w := range unique {
+		for j := i + 1; j < len(unique) && strings.HasPrefix(unique[j], w); j++ {
+			a.PrefixPairs = append(a.PrefixPairs, [2]string{w, unique[j]})
+		}
+	}
+	a.UniquePrefix = uniquePrefix(unique)
+	a.MinDistance, a.ClosePairs = closestPairs(unique)
+
+	total := 0
+	for i, w := range words {
+		n := utf8.RuneCountInString(w)
+		a.Lengths[n]++
+		total += n
+		if i == 0 || n < a.MinLength {
+			a.MinLength = n
+		}
+		if n > a.MaxLength {
+			a.MaxLength = n
+		}
+		if !isASCII(w) {
+			a.NonASCII = append(a.NonASCII, w)
+		}
+	}
+	a.MeanLength = float64(total) / float64(len(words))
+
+	groups := make(map[string][]string)
+	var keys []string
+	for _, w := range unique {
+		key := spellingKey(w)
+		if len(groups[key]) == 1 {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], w)
+	}
+	for _, key := range keys {
+		a.SimilarSpellings = append(a.SimilarSpellings, groups[key])
+	}
+	return a
+}
+
+// uniquePrefix returns the fewest letters that keep distinct words apart.
+func uniquePrefix(words []string) int {
+	longest := 0
+	for _, w := range words {
+		longest = max(longest, len(w))
+	}
+	for n := 1; n <= longest; n++ {
+		seen := make(map[string]bool, len(words))
+		ok := true
+		for _, w := range words {
+			p := w[:min(n, len(w))]
+			if seen[p] {
+				ok = false
+				break
+			}
+			seen[p] = true
+		}
+		if ok {
+			return n
+		}
+	}
+	return 0
+}
+
+// closestPairs returns the smallest edit distance between distinct words
+// and every pair that close.
+func closestPairs(words []string) (int, [][2]string) {
+	best := math.MaxInt
+	var pairs [][2]string
+	for i, a := range words {
+		for _, b := range words[i+1:] {
+			if abs(len(a)-len(b)) > best {
+				continue
+			}
+			d := editDistance(a, b, best)
+			switch {
+			case d < best:
+				best = d
+				pairs = [][2]string{{a, b}}
+			case d == best:
+				pairs = append(pairs, [2]string{a, b})
+			}
+		}
+	}
+	if best == math.MaxInt {
+		return 0, nil
+	}
+	return best, pairs
+}
+
+// editDistance is the Levenshtein distance between a and b in bytes, or
+// limit+1 once it is known to be more than limit.
+func editDistance(a, b string, limit int) int {
+	if limit > len(a)+len(b) {
+		limit = len(a) + len(b)
+	}
+	// word list words fit on the stack
+	var buf [2][32]int
+	prev, cur := buf[0][:], buf[1][:]
+	if len(b) >= len(prev) {
+		prev, cur = make([]int, len(b)+1), make([]int, len(b)+1)
+	}
+	prev, cur = prev[:len(b)+1], cur[:len(b)+1]
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// spellingRewrites folds English spellings that can make the same sound
+// into one form. Earlier pairs win where two match at the same place.
+var spellingRewrites = strings.NewReplacer(
+	"ph", "f", "ck", "k", "gh", "", "kn", "n", "wr", "r", "wh", "w",
+	"ce", "se", "ci", "si", "cy", "si", "c", "k", "q", "k", "x", "ks", "z", "s",
+	"ee", "i", "ea", "i", "ie", "i", "y", "i",
+	"ai", "a", "ay", "a", "ei", "a", "ey", "a",
+	"oa", "o", "ow", "o", "oo", "u", "ou", "u", "ue", "u", "ew", "u",
+)
+
+// spellingKey is a rough spelling key: words with the same key are spelled
+// alike, such as knight and night, or sale and sail. It is too rough to say
+// whether they sound alike.
+func spellingKey(word string) string {
+	s := spellingRewrites.Replace(strings.ToLower(word))
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if i > 0 && s[i] == s[i-1] {
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	key := b.String()
+	if len(key) > 3 {
+		key = strings.TrimSuffix(key, "e")
+	}
+	return key
+}
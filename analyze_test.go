@@ -0,0 +1,63 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeWords(t *testing.T) {
+	a := AnalyzeWords([]string{"sail", "sale", "tee", "Sail", "teeth", "café", "knight", "night"})
+	if a.Words != 8 || a.BitsPerWord >= 3 {
+		t.Errorf("unexpected %d words, %v bits", a.Words, a.BitsPerWord)
+	}
+	if !reflect.DeepEqual(a.Duplicates, []string{"sail"}) {
+		t.Errorf("unexpected duplicates %v", a.Duplicates)
+	}
+	if !reflect.DeepEqual(a.PrefixPairs, [][2]string{{"tee", "teeth"}}) {
+		t.Errorf("unexpected prefix pairs %v", a.PrefixPairs)
+	}
+	if a.UniquePrefix != 4 {
+		t.Errorf("unexpected unique prefix %d", a.UniquePrefix)
+	}
+	if a.MinDistance != 1 || !reflect.DeepEqual(a.ClosePairs, [][2]string{{"knight", "night"}}) {
+		t.Errorf("unexpected distance %d for %v", a.MinDistance, a.ClosePairs)
+	}
+	if a.MinLength != 3 || a.MaxLength != 6 || a.Lengths[4] != 4 {
+		t.Errorf("unexpected lengths %v", a.Lengths)
+	}
+	if !reflect.DeepEqual(a.NonASCII, []string{"café"}) {
+		t.Errorf("unexpected non-ASCII %v", a.NonASCII)
+	}
+	if !reflect.DeepEqual(a.SimilarSpellings, [][]string{{"knight", "night"}, {"sail", "sale"}}) {
+		t.Errorf("unexpected similar spellings %v", a.SimilarSpellings)
+	}
+}
+
+func TestAnalyzeShort2(t *testing.T) {
+	a := AnalyzeWords(List(Short2).Words())
+	if len(a.Duplicates) != 0 || len(a.PrefixPairs) != 0 || len(a.NonASCII) != 0 {
+		t.Errorf("unexpected problems %v %v %v", a.Duplicates, a.PrefixPairs, a.NonASCII)
+	}
+	// the point of the list
+	if a.UniquePrefix != 3 || a.MinDistance != 3 {
+		t.Errorf("unexpected unique prefix %d, distance %d", a.UniquePrefix, a.MinDistance)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	for _, test := range []struct {
+		a, b  string
+		limit int
+		want  int
+	}{
+		{"kitten", "sitting", 10, 3},
+		{"kitten", "sitting", 1, 2},
+		{"", "abc", 10, 3},
+		{"same", "same", 10, 0},
+	} {
+		if d := editDistance(test.a, test.b, test.limit); d != test.want {
+			t.Errorf("%s %s: expected %d, got %d", test.a, test.b, test.want, d)
+		}
+	}
+}
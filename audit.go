@@ -0,0 +1,191 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// AuditAlpha is the p-value below which, or above one minus which, an
+// audit test is flagged. Output that fits too well is as suspicious as
+// output that fits badly.
+const AuditAlpha = 1e-4
+
+// AuditResult is one goodness-of-fit test made by Audit or AuditApple.
+type AuditResult struct {
+	Name      string
+	Samples   int
+	ChiSquare float64
+	DF        int
+	P         float64
+	Anomaly   bool
+}
+
+// Audit makes n phrases of numWords words from dict and tests that word
+// indexes, the dice faces at each position of the rolls, and the first
+// dice of neighbouring words are uniform.
+func Audit(dict Dictionary, numWords, n int) ([]AuditResult, error) {
+	return AuditFrom(rand.Reader, dict, numWords, n)
+}
+
+// AuditFrom is Audit using random bytes from r.
+func AuditFrom(r io.Reader, dict Dictionary, numWords, n int) ([]AuditResult, error) {
+	l := List(dict)
+	dice := l.Dice()
+	indexes := make([]int, l.Len())
+	faces := make([][]int, dice)
+	for i := range faces {
+		faces[i] = make([]int, 6)
+	}
+	pairs := make([]int, 36)
+
+	s := newSource(r, 4096)
+	for i := 0; i < n; i++ {
+		p, err := phraseFrom(s, numWords, dict)
+		if err != nil {
+			return nil, err
+		}
+		prev := -1
+		for _, rolls := range p.Rolls {
+			idx, _ := rollsIndex(rolls, dice)
+			indexes[idx]++
+			first := 0
+			for pos := dice - 1; pos >= 0; pos-- {
+				first = rolls%10 - 1
+				faces[pos][first]++
+				rolls /= 10
+			}
+			if prev >= 0 {
+				pairs[prev*6+first]++
+			}
+			prev = first
+		}
+	}
+
+	results := []AuditResult{chiSquareUniform(fmt.Sprintf("%v word index", dict), indexes)}
+	for pos, counts := range faces {
+		results = append(results, chiSquareUniform(fmt.Sprintf("%v die %d face", dict, pos+1), counts))
+	}
+	if numWords > 1 {
+		results = append(results, chiSquareUniform(fmt.Sprintf("%v first dice of neighbouring words", dict), pairs))
+	}
+	return results, nil
+}
+
+// AuditApple makes n Apple style passwords and tests that the letters,
+// the positions of the capital and the digit, and the digits are uniform.
+func AuditApple(long bool, n int) ([]AuditResult, error) {
+	return AuditAppleFrom(rand.Reader, long, n)
+}
+
+// AuditAppleFrom is AuditApple using random bytes from r.
+func AuditAppleFrom(r io.Reader, long bool, n int) ([]AuditResult, error) {
+	numChars := 18
+	if long {
+		numChars = 24
+	}
+	letters := make([]int, 26)
+	capitals := make([]int, numChars)
+	digitPos := make([]int, numChars)
+	digits := make([]int, 10)
+
+	s := newSource(r, 4096)
+	for i := 0; i < n; i++ {
+		res, err := appleFrom(s, long)
+		if err != nil {
+			return nil, err
+		}
+		res = strings.ReplaceAll(res, "-", "")
+		for pos := 0; pos < len(res); pos++ {
+			c := res[pos]
+			switch {
+			case c >= 'a' && c <= 'z':
+				letters[c-'a']++
+			case c >= 'A' && c <= 'Z':
+				letters[c-'A']++
+				capitals[pos]++
+			case c >= '0' && c <= '9':
+				digits[c-'0']++
+				digitPos[pos]++
+			}
+		}
+	}
+
+	return []AuditResult{
+		chiSquareUniform("apple letters", letters),
+		chiSquareUniform("apple capital position", capitals),
+		chiSquareUniform("apple digit position", digitPos),
+		chiSquareUniform("apple digits", digits),
+	}, nil
+}
+
+// chiSquareUniform runs Pearson's chi-squared test of counts against the
+// uniform distribution.
+func chiSquareUniform(name string, counts []int) AuditResult {
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	res := AuditResult{Name: name, Samples: total, DF: len(counts) - 1, P: 1}
+	if total == 0 || res.DF < 1 {
+		return res
+	}
+	expected := float64(total) / float64(len(counts))
+	for _, c := range counts {
+		d := float64(c) - expected
+		res.ChiSquare += d * d / expected
+	}
+	res.P = gammaQ(float64(res.DF)/2, res.ChiSquare/2)
+	res.Anomaly = res.P < AuditAlpha || res.P > 1-AuditAlpha
+	return res
+}
+
+// gammaQ is the regularized upper incomplete gamma function Q(a, x), so
+// gammaQ(df/2, x/2) is the chance of a chi-squared statistic of at least x.
+// It uses the series for small x and a continued fraction for large x.
+func gammaQ(a, x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	lg, _ := math.Lgamma(a)
+	if x < a+1 {
+		sum, term := 1/a, 1/a
+		for n := 1.0; n < 100000; n++ {
+			term *= x / (a + n)
+			sum += term
+			if term < sum*1e-15 {
+				break
+			}
+		}
+		return 1 - sum*math.Exp(-x+a*math.Log(x)-lg)
+	}
+
+	// modified Lentz
+	const tiny = 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1.0; i < 100000; i++ {
+		an := -i * (i - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return math.Exp(-x+a*math.Log(x)-lg) * h
+}
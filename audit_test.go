@@ -0,0 +1,88 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"bytes"
+	"math"
+	mrand "math/rand/v2"
+	"testing"
+)
+
+func TestGammaQ(t *testing.T) {
+	// chi-squared critical values at p = 0.05
+	tests := []struct {
+		df int
+		x  float64
+	}{
+		{1, 3.841}, {5, 11.070}, {10, 18.307}, {35, 49.802}, {1000, 1074.679},
+	}
+	for _, test := range tests {
+		if p := gammaQ(float64(test.df)/2, test.x/2); math.Abs(p-0.05) > 1e-3 {
+			t.Errorf("df %d, x %v: expected 0.05, got %v", test.df, test.x, p)
+		}
+	}
+}
+
+func TestChiSquareUniform(t *testing.T) {
+	res := chiSquareUniform("even", []int{100, 100, 100, 100})
+	if res.ChiSquare != 0 || !res.Anomaly {
+		t.Errorf("a perfect fit should be flagged: %+v", res)
+	}
+
+	// the old makeApple never put the capital in the last position
+	counts := make([]int, 18)
+	for i := 0; i < 17; i++ {
+		counts[i] = 1000
+	}
+	res = chiSquareUniform("last position missing", counts)
+	if !res.Anomaly {
+		t.Errorf("expected an anomaly: %+v", res)
+	}
+}
+
+func TestAudit(t *testing.T) {
+	// a fixed ChaCha8 stream keeps the p-values, and so the test, the same
+	// on every run
+	seed := [32]byte{'d', 'i', 'c', 'e'}
+	for _, dict := range []Dictionary{Large, Short2} {
+		results, err := AuditFrom(mrand.NewChaCha8(seed), dict, 5, 20000)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, res := range results {
+			if res.Anomaly {
+				t.Errorf("unexpected anomaly: %+v", res)
+			}
+		}
+	}
+
+	results, err := AuditAppleFrom(mrand.NewChaCha8(seed), false, 20000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, res := range results {
+		if res.Anomaly {
+			t.Errorf("unexpected anomaly: %+v", res)
+		}
+	}
+}
+
+// TestAuditSystemSource only checks that auditing crypto/rand runs, since
+// any test on its p-values would fail now and then.
+func TestAuditSystemSource(t *testing.T) {
+	results, err := Audit(Short, 2, 1000)
+	if err != nil || len(results) != 6 {
+		t.Errorf("unexpected %d results, error %v", len(results), err)
+	}
+}
+
+func TestAuditStuckSource(t *testing.T) {
+	zeros := bytes.NewReader(make([]byte, 1<<20))
+	results, err := AuditFrom(zeros, Short, 4, 1000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !results[0].Anomaly {
+		t.Errorf("expected an anomaly: %+v", results[0])
+	}
+}
@@ -5,10 +5,13 @@ package main
 import (
 	"flag"
 	"fmt"
+	"log/slog"
+	"net/http/cgi"
 	"os"
 	"runtime/debug"
 
 	"github.com/timothyham/dicewords"
+	"github.com/timothyham/dicewords/web"
 )
 
 var numPhrases = flag.Int("p", 5, "Number of phrases to generate")
@@ -17,9 +20,17 @@ var numBits = flag.Int("b", 64, "Number of bits to generates")
 var short = flag.Bool("short", false, "Short words")
 var shortUniq = flag.Bool("short2", false, "Short words with unique beginning")
 var verbose = flag.Bool("v", false, "Print additional info")
+var debugLog = flag.Bool("debug", false, "Log every roll and word. Never use phrases made with it")
+var logLevel = flag.String("log-level", "info", "Log level: debug, info, warn or error")
 var version = flag.Bool("version", false, "Print version")
 var help = flag.Bool("h", false, "Print help")
 
+var serveOptions web.Options
+
+func init() {
+	serveOptions.AddFlags(flag.CommandLine)
+}
+
 func main() {
 	debug.SetMemoryLimit(5000000)
 	flag.Usage = func() {
@@ -37,6 +48,11 @@ func main() {
 		return
 	}
 
+	if err := setupLogging(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
 	conf := dicewords.MakeConfig()
 	if *short {
 		conf.Dict = dicewords.Short
@@ -48,49 +64,59 @@ func main() {
 	conf.NumBits = *numBits
 	conf.NumPhrases = *numPhrases
 
-	phrases, stats := dicewords.MakeWords(conf)
-
-	outWords := ""
+	handler := serveOptions.Handler(conf, *verbose)
 
-	for i, words := range phrases {
-		outWords += fmt.Sprintf("%s</br>", words)
-		if *verbose {
-			outWords += fmt.Sprintf("    %s</br>", dicewords.PrintStats(stats[i]))
+	mode, addr, err := serveOptions.Listen()
+	if err != nil {
+		fatal(err.Error())
+	}
+	if addr != "" {
+		server, err := serveOptions.Server(handler)
+		if err != nil {
+			fatal(err.Error())
 		}
+		dicewords.Logger.Info("serving", "mode", mode, "addr", addr)
+		fatal("serving", "err", server.ListenAndServe())
 	}
 
-	outApple := ""
-	applePhrases, _ := dicewords.MakeApple(dicewords.Config{NumPhrases: 5, AppleStyle: true}, false)
-	for _, words := range applePhrases {
-		outApple += fmt.Sprintf("%s</br>", words)
+	if err := dicewords.VerifyLists(); err != nil {
+		fatal(err.Error())
 	}
+	if err := cgi.Serve(handler); err != nil {
+		fatal("serving cgi", "err", err)
+	}
+}
 
-	fmt.Printf(template, outWords, outApple)
+// setupLogging logs to stderr at -log-level, or at debug level with the
+// roll and word trace turned on for -debug.
+func setupLogging() error {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(*logLevel)); err != nil {
+		return fmt.Errorf("bad -log-level %q", *logLevel)
+	}
+	if *debugLog {
+		level = slog.LevelDebug
+	}
+	dicewords.Logger = dicewords.NewLogger(os.Stderr, level)
+	if *debugLog {
+		dicewords.EnableDebug()
+	}
+	return nil
 }
 
-var template string = `Content-type: text/html
-
-<html>
-<head>
-<meta http-equiv="Content-Type" content="text/html; charset=UTF-8">
-<title>Dicewords</title>
-</head>
-<body>
-Each line of random words is about 65 bits</br></br>
-%s
-</br></br>
-Apple style passwords with about 80 bits</br></br>
-%s
-</br>
-</body>
-</html>
-
-`
+func fatal(msg string, args ...any) {
+	dicewords.Logger.Error(msg, args...)
+	os.Exit(1)
+}
 
 func printHelp() {
 	helpText := `
 dicewords - print EFF dicewords
 
+Runs as CGI, or serves HTTP, FastCGI or SCGI itself with -http, -fcgi or -scgi.
+The response is plain text, JSON or HTML depending on the Accept header.
+Add ?format=text, ?format=json or ?format=html to the URL to override it.
+
 options:
 -version 
     Show version.
@@ -98,8 +124,6 @@ options:
     Show this help.
 -b
     Target number of bits. Default is 64 bits.
--cgi
-	Use html linebreak </br> instead of \n
 -p 
     Number of passphrases to generate. Default is 5.
 -w
@@ -110,8 +134,13 @@ options:
     Use eff short unique 3 letter beginning words list.
 -v
     Show additional information.
-`
-	fmt.Printf(helpText)
+-log-level
+    Log level: debug, info, warn or error. Default is info.
+-debug
+    Log every roll, list index and word. The log reveals the phrases, so
+    never use them.
+` + web.FlagsHelp
+	fmt.Print(helpText)
 }
 
 func printVersion() {
@@ -0,0 +1,109 @@
+// Copyright 2026 Timothy Ham
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/timothyham/dicewords"
+)
+
+const adviseHelp = `
+usage: dicewords advise [options]
+
+Recommend how many words from each list, or which Apple style password, a
+password needs to hold out against an attacker for a number of years on
+average, with the command that makes it.
+
+options:
+-offline-hash
+    The password hash an attacker who steals it has to guess through:
+    ntlm, md5, bcrypt or argon2id. Default is ntlm, for when you don't know.
+-attacker
+    Any attacker from the table instead, such as online or
+    online-throttled. Can't be used with -offline-hash.
+-years
+    How long the password should hold out. Default is 100. It can't need
+    more than 20 words from every list.
+-list
+    Show the attacker table.
+` + attackersHelp
+
+func runAdvise(args []string) error {
+	fs := newFlagSet("advise", adviseHelp)
+	hash := fs.String("offline-hash", "ntlm", "Password hash an offline attacker faces")
+	name := fs.String("attacker", "", "Attacker from the table")
+	years := fs.Float64("years", 100, "Years the password should hold out")
+	list := fs.Bool("list", false, "Show the attacker table")
+	var af attackersFlag
+	af.add(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+	if err := exclusive(setFlags(fs), "offline-hash", "attacker"); err != nil {
+		return err
+	}
+	attackers, err := af.load()
+	if err != nil {
+		return err
+	}
+
+	if *list {
+		for _, a := range attackers {
+			fmt.Printf("%-18s %10.3g guesses/s  %s\n", a.Name, a.Rate, a.Description)
+		}
+		return nil
+	}
+	if !(*years > 0) {
+		return usagef("-years must be above 0")
+	}
+	if *name == "" {
+		*name = *hash
+	}
+	attacker, ok := dicewords.FindAttacker(attackers, *name)
+	if !ok {
+		return usagef("unknown attacker %q, see dicewords advise -list", *name)
+	}
+
+	a, err := dicewords.Advise(attacker, *years*dicewords.SecondsPerYear)
+	if errors.Is(err, dicewords.ErrTooStrong) {
+		return usagef("%.1f bits are needed, more than %d words of any list give; ask for fewer -years", a.Bits, dicewords.MaxWords)
+	}
+	if err != nil {
+		return usagef("%v", err)
+	}
+	fmt.Printf("Holding out %s on average against %s (%s)\nneeds %.1f bits.\n\n",
+		dicewords.FormatSeconds(a.Seconds), attacker.Name, attacker.Description, a.Bits)
+	for _, p := range a.Phrases {
+		if p.NumWords == 0 {
+			fmt.Printf("%-8s needs over %d words\n", p.Dict, dicewords.MaxWords)
+			continue
+		}
+		command := fmt.Sprintf("dicewords generate -w %d", p.NumWords)
+		if p.Dict != dicewords.Large {
+			command += " -" + p.Dict.String()
+		}
+		printAdvice(p.Dict.String(), p.NumWords, "word", p.Bits, "about", p.Length, command)
+	}
+	switch {
+	case a.Apple.Bits == 0:
+		fmt.Printf("%-8s not strong enough\n", "apple")
+	case a.Apple.Long:
+		printAdvice("apple", 4, "group", a.Apple.Bits, "", a.Apple.Length, "dicewords apple -long")
+	default:
+		printAdvice("apple", 3, "group", a.Apple.Bits, "", a.Apple.Length, "dicewords apple")
+	}
+	return nil
+}
+
+// printAdvice writes one row of advice, n units long, with length typical
+// if about is "about" or exact if it is "".
+func printAdvice(name string, n int, unit string, bits float64, about string, length int, command string) {
+	if n != 1 {
+		unit += "s"
+	}
+	fmt.Printf("%-8s %2d %-7s %5.1f bits, %5s %3d chars   %s\n", name, n, unit+",", bits, about, length, command)
+}
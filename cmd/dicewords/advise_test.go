@@ -0,0 +1,57 @@
+// Copyright 2026 Timothy Ham
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAdviseCommand(t *testing.T) {
+	out, _, err := capture(t, func() error {
+		return runAdvise([]string{"--offline-hash=bcrypt", "--years=100"})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"against bcrypt",
+		"needs 48.0 bits",
+		"large     4 words,   51.7 bits, about  31 chars   dicewords generate -w 4\n",
+		"dicewords generate -w 5 -short\n",
+		"dicewords generate -w 5 -short2\n",
+		"apple     3 groups,  87.0 bits,        20 chars   dicewords apple\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("no %q in output:\n%s", want, out)
+		}
+	}
+
+	out, _, err = capture(t, func() error {
+		return runAdvise([]string{"-attacker", "online-throttled", "-years", "0.001"})
+	})
+	if err != nil || !strings.Contains(out, "large     1 word, ") {
+		t.Errorf("unexpected output %v:\n%s", err, out)
+	}
+
+	out, _, err = capture(t, func() error { return runAdvise([]string{"-list"}) })
+	if err != nil || !strings.Contains(out, "argon2id") {
+		t.Errorf("unexpected list %v:\n%s", err, out)
+	}
+
+	for _, args := range [][]string{
+		{"-years", "1e300"},
+		{"-years", "0"},
+		{"-years", "-5"},
+		{"-years", "NaN"},
+		{"-years", "Inf"},
+		{"-attacker", "nobody"},
+		{"-attacker", "online", "-offline-hash", "md5"},
+	} {
+		out, _, err := capture(t, func() error { return runAdvise(args) })
+		var ue usageError
+		if !errors.As(err, &ue) || out != "" {
+			t.Errorf("%q: expected usage error and no output, got %v:\n%s", args, err, out)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 Timothy Ham
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/timothyham/dicewords"
+)
+
+const analyzeListHelp = `
+usage: dicewords analyze-list [options] [file...]
+
+Check word lists before adopting them: size and bits per word, duplicate
+words, words that start other words (which makes phrases typed without
+spaces ambiguous), how many letters tell every word apart, the smallest edit
+distance between two words, word lengths, non-ASCII characters and words
+with similar spellings, some of which sound alike. Lists where words run
+together without spaces can be read more than one way show the strength
+lost by a phrase of 6 words.
+
+A file has one word per line, or "rolls<TAB>word" lines like the EFF lists,
+in which case the rolls are checked too. Without a file the built-in list is
+analyzed.
+
+options:
+` + dictHelp + `-v
+    Show every problem found, not just the first few.
+`
+
+func runAnalyzeList(args []string) error {
+	fs := newFlagSet("analyze-list", analyzeListHelp)
+	verbose := fs.Bool("v", false, "Show every problem found")
+	var df dictFlags
+	df.add(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	dict, err := df.dict()
+	if err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		l := dicewords.List(dict)
+		printAnalysis(l.Name(), fmt.Sprintf("ok, %d dice", l.Dice()), l.Words(), *verbose)
+		return nil
+	}
+	if df.short || df.short2 {
+		return usagef("-short and -short2 are for the built-in lists, not files")
+	}
+	for i, path := range fs.Args() {
+		if i > 0 {
+			fmt.Println()
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		words, format := listWords(path, string(data))
+		printAnalysis(path, format, words, *verbose)
+	}
+	return nil
+}
+
+// listWords returns the words of a list file, and whether it loads as a
+// diceware list.
+func listWords(name, data string) ([]string, string) {
+	var words []string
+	withRolls := false
+	for _, line := range strings.Split(strings.TrimRight(data, "\r\n"), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if _, word, ok := strings.Cut(line, "\t"); ok {
+			line, withRolls = word, true
+		}
+		words = append(words, line)
+	}
+	if !withRolls {
+		return words, "plain words, no rolls"
+	}
+	l, err := dicewords.ReadWordList(name, strings.NewReader(data))
+	if err != nil {
+		return words, err.Error()
+	}
+	return words, fmt.Sprintf("ok, %d dice", l.Dice())
+}
+
+func printAnalysis(name, format string, words []string, verbose bool) {
+	a := dicewords.AnalyzeWords(words)
+	limit := 5
+	if verbose {
+		limit = -1
+	}
+
+	fmt.Printf("%-18s %s\n", "list", name)
+	fmt.Printf("%-18s %s\n", "format", format)
+	fmt.Printf("%-18s %d\n", "words", a.Words)
+	fmt.Printf("%-18s %.2f\n", "bits/word", a.BitsPerWord)
+	fmt.Printf("%-18s %s\n", "duplicates", examples(a.Duplicates, limit))
+	pairs := joinPairs(a.PrefixPairs, "/")
+	if len(pairs) == 0 {
+		fmt.Printf("%-18s none, safe to type without spaces\n", "prefixes")
+	} else {
+		fmt.Printf("%-18s %s\n", "prefixes", examples(pairs, limit))
+	}
+	if dicewords.UniquelyDecodable(words) {
+		fmt.Printf("%-18s uniquely decodable\n", "without spaces")
+	} else if loss, err := dicewords.SeparatorLoss(words, 6, 10000); err == nil {
+		fmt.Printf("%-18s not uniquely decodable, 6 words lose %.2f of %.1f bits\n", "without spaces", loss, 6*a.BitsPerWord)
+	}
+	if a.UniquePrefix == 0 {
+		fmt.Printf("%-18s none\n", "unique prefix")
+	} else {
+		fmt.Printf("%-18s %d letters\n", "unique prefix", a.UniquePrefix)
+	}
+	fmt.Printf("%-18s %d: %s\n", "min edit distance", a.MinDistance, examples(joinPairs(a.ClosePairs, "/"), limit))
+	fmt.Printf("%-18s %d to %d, mean %.1f\n", "length", a.MinLength, a.MaxLength, a.MeanLength)
+	lengths := make([]int, 0, len(a.Lengths))
+	for n := range a.Lengths {
+		lengths = append(lengths, n)
+	}
+	sort.Ints(lengths)
+	for _, n := range lengths {
+		fmt.Printf("%18d %d\n", n, a.Lengths[n])
+	}
+	fmt.Printf("%-18s %s\n", "non-ASCII", examples(a.NonASCII, limit))
+	var groups []string
+	for _, g := range a.SimilarSpellings {
+		groups = append(groups, strings.Join(g, "/"))
+	}
+	fmt.Printf("%-18s %s\n", "similar spellings", examples(groups, limit))
+}
+
+func joinPairs(pairs [][2]string, sep string) []string {
+	var out []string
+	for _, p := range pairs {
+		out = append(out, p[0]+sep+p[1])
+	}
+	return out
+}
+
+// examples shows up to limit of items, all of them if limit is negative.
+func examples(items []string, limit int) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	if limit < 0 || len(items) <= limit {
+		return fmt.Sprintf("%d: %s", len(items), strings.Join(items, ", "))
+	}
+	return fmt.Sprintf("%d, like %s, ...", len(items), strings.Join(items[:limit], ", "))
+}
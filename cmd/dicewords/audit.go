@@ -0,0 +1,80 @@
+// Copyright 2026 Timothy Ham
+package main
+
+import (
+	"fmt"
+
+	"github.com/timothyham/dicewords"
+)
+
+const auditHelp = `
+usage: dicewords audit [options]
+
+Generate many phrases from every list, and many Apple style passwords, and
+test that the output is uniform: word indexes, the dice faces at each
+position, the first dice of neighbouring words, and the letters, digits and
+capital and digit positions of Apple style passwords. Each test is Pearson's
+chi-squared; a p-value below 0.0001 or above 0.9999 is flagged. About one run
+in a thousand flags something by chance, so run again before worrying.
+Exits with status 1 if anything is flagged.
+
+options:
+-n
+    Number of phrases and passwords to make for each test. Default is 100000.
+-w
+    Number of words per phrase. Default is 6.
+`
+
+func runAudit(args []string) error {
+	fs := newFlagSet("audit", auditHelp)
+	n := fs.Int("n", 100000, "Number of phrases per test")
+	numWords := fs.Int("w", 6, "Number of words per phrase")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+	if *n < 1 {
+		return usagef("-n must be at least 1")
+	}
+	if *numWords < 1 {
+		return usagef("-w must be at least 1")
+	}
+
+	var results []dicewords.AuditResult
+	for _, dict := range []dicewords.Dictionary{dicewords.Large, dicewords.Short, dicewords.Short2} {
+		res, err := dicewords.Audit(dict, *numWords, *n)
+		if err != nil {
+			return err
+		}
+		results = append(results, res...)
+	}
+	for _, long := range []bool{false, true} {
+		res, err := dicewords.AuditApple(long, *n)
+		if err != nil {
+			return err
+		}
+		if long {
+			for i := range res {
+				res[i].Name = "long " + res[i].Name
+			}
+		}
+		results = append(results, res...)
+	}
+
+	flagged := 0
+	fmt.Printf("%-40s %9s %12s %5s %8s\n", "test", "samples", "chi-squared", "df", "p")
+	for _, r := range results {
+		mark := ""
+		if r.Anomaly {
+			mark = "  ANOMALY"
+			flagged++
+		}
+		fmt.Printf("%-40s %9d %12.1f %5d %8.4f%s\n", r.Name, r.Samples, r.ChiSquare, r.DF, r.P, mark)
+	}
+	if flagged > 0 {
+		return fmt.Errorf("%d of %d tests flagged", flagged, len(results))
+	}
+	return nil
+}
@@ -2,95 +2,273 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"log/slog"
 	"os"
+	"strings"
 
 	"github.com/timothyham/dicewords"
 )
 
-var numPhrases = flag.Int("p", 5, "Number of phrases to generate")
-var numWords = flag.Int("w", 0, "Number of words per passphrase")
-var numBits = flag.Int("b", 64, "Number of bits to generates")
-var appleStyle = flag.Bool("apple", false, "Generate Apple style password")
-var appleStyle2 = flag.Bool("apple2", false, "Generate long Apple style password")
-var short = flag.Bool("short", false, "Short words")
-var shortUniq = flag.Bool("short2", false, "Short words with unique beginning")
-var verbose = flag.Bool("v", false, "Print additional info")
-var version = flag.Bool("version", false, "Print version")
-var help = flag.Bool("h", false, "Print help")
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"generate", "Generate passphrases. The default command.", runGenerate},
+		{"apple", "Generate Apple style passwords.", runApple},
+		{"roll", "Roll dice for a passphrase and show each roll and word.", runRoll},
+		{"lookup", "Look up the word for rolls, or the rolls for a word.", runLookup},
+		{"check", "Check that a passphrase only uses words from a list.", runCheck},
+		{"strength", "Estimate how guessable any password is.", runStrength},
+		{"advise", "Recommend words per list for a time against an attacker.", runAdvise},
+		{"expand", "Turn a compact -short2 password back into words.", runExpand},
+		{"lists", "Show the word lists.", runLists},
+		{"analyze-list", "Check a word list for duplicates, prefixes, typos and similar spellings.", runAnalyzeList},
+		{"mklist", "Make a word list from a text corpus or word frequencies.", runMklist},
+		{"selftest", "Test the word lists and the random source.", runSelftest},
+		{"audit", "Test that generated phrases and passwords are uniform.", runAudit},
+		{"serve", "Serve passphrases over HTTP, FastCGI or SCGI.", runServe},
+		{"version", "Show version.", runVersion},
+		{"help", "Show this help.", runHelp},
+	}
+}
+
+// usageError is a command line mistake, which exits with status 2.
+// shown is set when the flag package has already printed it.
+type usageError struct {
+	err   error
+	shown bool
+}
+
+func (e usageError) Error() string {
+	return e.err.Error()
+}
+
+func usagef(format string, args ...any) error {
+	return usageError{err: fmt.Errorf(format, args...)}
+}
 
 func main() {
-	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
-		printHelp()
+	args := os.Args[1:]
+	cmd := commands[0]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		c, ok := findCommand(args[0])
+		if !ok {
+			fmt.Fprintf(os.Stderr, "dicewords: unknown command %q\n", args[0])
+			fmt.Fprint(os.Stderr, commandsHelp())
+			os.Exit(2)
+		}
+		cmd, args = c, args[1:]
 	}
-	flag.Parse()
-	if *help {
-		printHelp()
-		return
+
+	err := cmd.run(args)
+	var ue usageError
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+	case errors.As(err, &ue):
+		if !ue.shown {
+			fmt.Fprintf(os.Stderr, "dicewords %s: %v\n", cmd.name, err)
+		}
+		os.Exit(2)
+	default:
+		fmt.Fprintf(os.Stderr, "dicewords %s: %v\n", cmd.name, err)
+		os.Exit(1)
 	}
+}
 
-	if *version {
-		printVersion()
-		return
+func findCommand(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
 	}
+	return command{}, false
+}
+
+func commandsHelp() string {
+	var b strings.Builder
+	b.WriteString(`
+dicewords - print EFF dicewords
+
+usage: dicewords [command] [options]
 
-	conf := dicewords.MakeConfig()
-	if *short {
-		conf.Dict = dicewords.Short
-	} else if *shortUniq {
-		conf.Dict = dicewords.Short2
+commands:
+`)
+	for _, c := range commands {
+		fmt.Fprintf(&b, "%-14s%s\n", c.name, c.summary)
 	}
+	b.WriteString(`
+Without a command, dicewords runs generate. Type dicewords <command> -h for
+the options of a command.
+`)
+	return b.String()
+}
+
+const logHelp = `-log-level
+    Log level: debug, info, warn or error. Default is warn.
+-debug
+    Log every roll, list index and word. The log reveals the phrases, so
+    never use them.
+`
+
+var debugLog bool
+var logLevel string
 
-	conf.NumWords = *numWords
-	conf.NumBits = *numBits
-	conf.NumPhrases = *numPhrases
-	var phrases []string
-	var stats []dicewords.Stats
-	if *appleStyle {
-		phrases, stats = dicewords.MakeApple(conf, false)
-	} else if *appleStyle2 {
-		phrases, stats = dicewords.MakeApple(conf, true)
-	} else {
-		phrases, stats = dicewords.MakeWords(conf)
+// newFlagSet returns the flag set for a command, with the logging flags
+// every command takes. Its usage shows help followed by the logging flags.
+func newFlagSet(name, help string) *flag.FlagSet {
+	fs := flag.NewFlagSet("dicewords "+name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), help+logHelp)
 	}
+	fs.BoolVar(&debugLog, "debug", false, "Log every roll and word. Never use phrases made with it")
+	fs.StringVar(&logLevel, "log-level", "warn", "Log level: debug, info, warn or error")
+	return fs
+}
 
-	for i, words := range phrases {
-		fmt.Printf("%s\n", words)
-		if *verbose {
-			fmt.Printf("    %s\n", dicewords.PrintStats(stats[i]))
+// parse parses args into fs and sets up logging. Errors are usage errors.
+func parse(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
 		}
+		return usageError{err: err, shown: true}
+	}
+	if err := setupLogging(); err != nil {
+		return usageError{err: err}
 	}
+	return nil
 }
 
-func printHelp() {
-	helpText := `
-dicewords - print EFF dicewords
+// setupLogging logs to stderr at -log-level, or at debug level with the
+// roll and word trace turned on for -debug.
+func setupLogging() error {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(logLevel)); err != nil {
+		return fmt.Errorf("bad -log-level %q", logLevel)
+	}
+	if debugLog {
+		level = slog.LevelDebug
+	}
+	dicewords.Logger = dicewords.NewLogger(os.Stderr, level)
+	if debugLog {
+		dicewords.EnableDebug()
+	}
+	return nil
+}
+
+// setFlags returns the names of the flags given on the command line.
+func setFlags(fs *flag.FlagSet) map[string]bool {
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	return set
+}
+
+// exclusive fails if more than one of names was given.
+func exclusive(set map[string]bool, names ...string) error {
+	given := []string{}
+	for _, name := range names {
+		if set[name] {
+			given = append(given, "-"+name)
+		}
+	}
+	if len(given) > 1 {
+		return usagef("%s can't be used together", strings.Join(given, " and "))
+	}
+	return nil
+}
+
+// noArgs fails if fs has arguments left over.
+func noArgs(fs *flag.FlagSet) error {
+	if fs.NArg() > 0 {
+		return usagef("unexpected argument %q", fs.Arg(0))
+	}
+	return nil
+}
+
+// dictFlags are the -short and -short2 flags choosing a word list.
+type dictFlags struct {
+	short, short2 bool
+}
 
-options:
--version 
-    Show version.
--help 
-    Show this help.
--b
-    Target number of bits. Default is 64 bits.
--p 
-    Number of passphrases to generate. Default is 5.
--w
-    Number of words per passphrase. Overrides -b.
--short
+const dictHelp = `-short
     Use eff short words list.
 -short2
     Use eff short unique 3 letter beginning words list.
--apple
-	Make Apple style password
--apple2
-	Make long version of Apple style password
--v
-    Show additional information.
 `
-	fmt.Printf(helpText)
+
+func (d *dictFlags) add(fs *flag.FlagSet) {
+	fs.BoolVar(&d.short, "short", false, "Short words")
+	fs.BoolVar(&d.short2, "short2", false, "Short words with unique beginning")
+}
+
+func (d *dictFlags) dict() (dicewords.Dictionary, error) {
+	switch {
+	case d.short && d.short2:
+		return dicewords.Large, usagef("-short and -short2 can't be used together")
+	case d.short:
+		return dicewords.Short, nil
+	case d.short2:
+		return dicewords.Short2, nil
+	}
+	return dicewords.Large, nil
+}
+
+// attackersFlag is the -attackers flag replacing the attacker models that
+// crack times are estimated for.
+type attackersFlag struct {
+	path string
+}
+
+const attackersHelp = `-attackers
+    Estimate crack times with the attackers in this JSON file instead of the
+    built in ones: an array of {"name", "description", "rate", "costPerHour"}
+    with rate in guesses per second and cost in dollars.
+`
+
+func (a *attackersFlag) add(fs *flag.FlagSet) {
+	fs.StringVar(&a.path, "attackers", "", "JSON file of attacker models")
+}
+
+// load returns the attackers in the file, or the built in ones if no file
+// was given.
+func (a *attackersFlag) load() ([]dicewords.Attacker, error) {
+	if a.path == "" {
+		return dicewords.DefaultAttackers(), nil
+	}
+	return dicewords.LoadAttackers(a.path)
+}
+
+func runVersion(args []string) error {
+	fs := newFlagSet("version", "\nusage: dicewords version\n\nShow version.\n\noptions:\n")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+	printVersion()
+	return nil
+}
+
+func runHelp(args []string) error {
+	if len(args) > 0 {
+		if c, ok := findCommand(args[0]); ok && c.name != "help" {
+			return c.run([]string{"-h"})
+		}
+	}
+	fmt.Print(commandsHelp())
+	return nil
 }
 
 func printVersion() {
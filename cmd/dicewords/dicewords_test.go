@@ -0,0 +1,59 @@
+// Copyright 2026 Timothy Ham
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFindCommand(t *testing.T) {
+	for _, c := range commands {
+		got, ok := findCommand(c.name)
+		if !ok || got.name != c.name {
+			t.Errorf("%s: unexpected %v %v", c.name, got.name, ok)
+		}
+	}
+	if _, ok := findCommand("nonsense"); ok {
+		t.Errorf("expected no command")
+	}
+	if commands[0].name != "generate" {
+		t.Errorf("expected generate to be the default, got %s", commands[0].name)
+	}
+}
+
+func TestExclusive(t *testing.T) {
+	set := map[string]bool{"w": true, "short": true}
+	if err := exclusive(set, "w", "b"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	set["b"] = true
+	err := exclusive(set, "w", "b")
+	var ue usageError
+	if !errors.As(err, &ue) || err.Error() != "-w and -b can't be used together" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestConflictingFlags(t *testing.T) {
+	for _, args := range [][]string{
+		{"-w", "5", "-b", "60"},
+		{"-short", "-short2"},
+		{"-apple", "-apple2"},
+		{"-apple", "-short"},
+		{"-apple", "-w", "4"},
+		{"-o", "json", "-format", "{{.Phrase}}"},
+		{"-caps"},
+		{"-compact"},
+		{"-w", "-3"},
+		{"-p", "-2"},
+		{"-b", "-64"},
+		{"-workers", "0"},
+		{"extra"},
+	} {
+		err := runGenerate(args)
+		var ue usageError
+		if !errors.As(err, &ue) {
+			t.Errorf("%q: expected usage error, got %v", args, err)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2018 Timothy Ham
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/timothyham/dicewords"
+)
+
+const generateHelp = `
+generate options:
+-version 
+    Show version.
+-help 
+    Show this help.
+-b
+    Target number of bits. Default is 64 bits.
+-p 
+    Number of passphrases to generate. Default is 5.
+-w
+    Number of words per passphrase. Can't be used with -b.
+` + dictHelp + `-apple
+    Make Apple style password, like dicewords apple.
+-apple2
+    Make long version of Apple style password, like dicewords apple -long.
+-v
+    Show additional information, including how long attackers would take
+    to guess each phrase and what it would cost them.
+` + attackersHelp + `-nosep
+    Run the words together without spaces. The EFF lists are prefix-free,
+    so such phrases still read only one way and lose no strength.
+-compact
+    With -short2, also show a compact password of the first 3 letters of
+    each word. It is as strong as the phrase; dicewords expand gives the
+    words back.
+-caps
+    Capitalize each word of the compact password.
+-pwned
+    Replace any phrase found in this local copy of the Pwned Passwords
+    SHA-1 list, with lines of hash:count sorted by hash.
+-workers
+    Generate with this many goroutines, at most four per CPU. The order of
+    phrases is kept.
+-seed
+    Make the same phrases every time from this seed, whatever -workers is.
+    Anyone with the seed has the phrases: for test fixtures only.
+` + outputHelp + formatHelp
+
+func runGenerate(args []string) error {
+	fs := newFlagSet("generate", commandsHelp()+generateHelp)
+	numPhrases := fs.Int("p", 5, "Number of phrases to generate")
+	numWords := fs.Int("w", 0, "Number of words per passphrase")
+	numBits := fs.Int("b", 64, "Number of bits to generates")
+	appleStyle := fs.Bool("apple", false, "Generate Apple style password")
+	appleStyle2 := fs.Bool("apple2", false, "Generate long Apple style password")
+	verbose := fs.Bool("v", false, "Print additional info")
+	format := fs.String("o", "text", "Output format: text, json, ndjson or csv")
+	tmpl := fs.String("format", "", "Template for each phrase")
+	noSep := fs.Bool("nosep", false, "Run the words together without spaces")
+	compact := fs.Bool("compact", false, "Also show a compact password of word prefixes")
+	caps := fs.Bool("caps", false, "Capitalize the compact password")
+	pwned := fs.String("pwned", "", "Pwned Passwords SHA-1 file to reject phrases from")
+	workers := fs.Int("workers", 1, "Goroutines generating phrases")
+	seed := fs.String("seed", "", "Make reproducible phrases from this seed, for test fixtures only")
+	version := fs.Bool("version", false, "Print version")
+	var df dictFlags
+	df.add(fs)
+	var af attackersFlag
+	af.add(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+	attackers, err := af.load()
+	if err != nil {
+		return err
+	}
+
+	if *version {
+		printVersion()
+		return nil
+	}
+
+	if *numPhrases < 0 {
+		return usagef("-p must be at least 0")
+	}
+	if *numWords < 0 {
+		return usagef("-w must be at least 1")
+	}
+	if *numBits < 0 {
+		return usagef("-b must be at least 1")
+	}
+	if *workers < 1 {
+		return usagef("-workers must be at least 1")
+	}
+
+	set := setFlags(fs)
+	if err := exclusive(set, "w", "b"); err != nil {
+		return err
+	}
+	if err := exclusive(set, "apple", "apple2"); err != nil {
+		return err
+	}
+	for _, apple := range []string{"apple", "apple2"} {
+		for _, other := range []string{"short", "short2", "w", "b", "nosep", "compact", "pwned", "workers", "seed"} {
+			if err := exclusive(set, apple, other); err != nil {
+				return err
+			}
+		}
+	}
+	dict, err := df.dict()
+	if err != nil {
+		return err
+	}
+	if *compact && dicewords.List(dict).UniquePrefix() == 0 {
+		return usagef("-compact needs -short2, the list with unique prefixes")
+	}
+	if *caps && !*compact {
+		return usagef("-caps needs -compact")
+	}
+
+	if err := exclusive(setFlags(fs), "o", "format"); err != nil {
+		return err
+	}
+	out, err := newOutput(os.Stdout, *format, *tmpl, *verbose, attackers)
+	if err != nil {
+		return err
+	}
+
+	conf := dicewords.MakeConfig()
+	conf.Dict = dict
+	conf.NumWords = *numWords
+	conf.NumBits = *numBits
+	conf.NumPhrases = *numPhrases
+
+	if *appleStyle || *appleStyle2 {
+		return writeApple(out, conf, *appleStyle2)
+	}
+
+	conf.NoSeparator = *noSep
+	conf.Workers = *workers
+	if *seed != "" {
+		fmt.Fprintln(os.Stderr, "dicewords: -seed makes the same phrases for anyone with the seed. Never use them as passwords.")
+		conf.Seed = []byte(*seed)
+	}
+	var rejected atomic.Int64
+	if *pwned != "" {
+		pl, err := dicewords.OpenPwnedList(*pwned)
+		if err != nil {
+			return err
+		}
+		defer pl.Close()
+		conf.Reject = func(p dicewords.Phrase) (bool, error) {
+			n, err := pl.Count(p.Phrase)
+			if n > 0 {
+				rejected.Add(1)
+			}
+			return n > 0, err
+		}
+	}
+	err = dicewords.Stream(conf, func(i int, p dicewords.Phrase) error {
+		r := newRecord(i, p)
+		if *compact {
+			c, err := dicewords.Compact(p, *caps)
+			if err != nil {
+				return err
+			}
+			r.Compact = c
+		}
+		return out.write(r)
+	})
+	if err != nil {
+		return err
+	}
+	switch n := rejected.Load(); {
+	case n == 1:
+		fmt.Fprintf(os.Stderr, "dicewords: replaced 1 phrase found in %s\n", *pwned)
+	case n > 1:
+		fmt.Fprintf(os.Stderr, "dicewords: replaced %d phrases found in %s\n", n, *pwned)
+	}
+	return out.close()
+}
+
+func writeApple(out output, conf dicewords.Config, long bool) error {
+	phrases, stats := dicewords.MakeApple(conf, long)
+	for i, phrase := range phrases {
+		if err := out.write(appleRecord(i, phrase, stats[i], long)); err != nil {
+			return err
+		}
+	}
+	return out.close()
+}
+
+const appleHelp = `
+usage: dicewords apple [options]
+
+Generate Apple style passwords: three groups of six letters with one capital
+and one digit, like abcdef-ghiJkl-mn4pqr.
+
+options:
+-p 
+    Number of passwords to generate. Default is 5.
+-long
+    Use four groups instead of three.
+-v
+    Show additional information, including how long attackers would take
+    to guess each password and what it would cost them.
+` + attackersHelp + outputHelp + formatHelp
+
+func runApple(args []string) error {
+	fs := newFlagSet("apple", appleHelp)
+	numPhrases := fs.Int("p", 5, "Number of passwords to generate")
+	long := fs.Bool("long", false, "Use four groups")
+	verbose := fs.Bool("v", false, "Print additional info")
+	format := fs.String("o", "text", "Output format: text, json, ndjson or csv")
+	tmpl := fs.String("format", "", "Template for each phrase")
+	var af attackersFlag
+	af.add(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+	attackers, err := af.load()
+	if err != nil {
+		return err
+	}
+	if err := exclusive(setFlags(fs), "o", "format"); err != nil {
+		return err
+	}
+	out, err := newOutput(os.Stdout, *format, *tmpl, *verbose, attackers)
+	if err != nil {
+		return err
+	}
+
+	return writeApple(out, dicewords.Config{NumPhrases: *numPhrases, AppleStyle: true}, *long)
+}
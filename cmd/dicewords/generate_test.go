@@ -0,0 +1,68 @@
+// Copyright 2026 Timothy Ham
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// capture runs f with standard output and error going to files, and
+// returns what was written to each.
+func capture(t *testing.T, f func() error) (string, string, error) {
+	t.Helper()
+	dir := t.TempDir()
+	stdout, err := os.Create(filepath.Join(dir, "stdout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr, err := os.Create(filepath.Join(dir, "stderr"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldOut, oldErr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = stdout, stderr
+	ferr := f()
+	os.Stdout, os.Stderr = oldOut, oldErr
+	stdout.Close()
+	stderr.Close()
+
+	out, _ := os.ReadFile(stdout.Name())
+	errOut, _ := os.ReadFile(stderr.Name())
+	return string(out), string(errOut), ferr
+}
+
+func TestGeneratePwned(t *testing.T) {
+	args := []string{"-seed", "pwned test", "-p", "4", "-w", "4"}
+	out, _, err := capture(t, func() error { return runGenerate(args) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Split(strings.TrimSpace(out), "\n")
+	if len(want) != 4 {
+		t.Fatalf("unexpected output %q", out)
+	}
+
+	sum := sha1.Sum([]byte(want[1]))
+	path := filepath.Join(t.TempDir(), "pwned.txt")
+	line := fmt.Sprintf("%s:42\n", strings.ToUpper(hex.EncodeToString(sum[:])))
+	if err := os.WriteFile(path, []byte(line), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, errOut, err := capture(t, func() error { return runGenerate(append(args, "-pwned", path)) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Split(strings.TrimSpace(out), "\n")
+	if len(got) != 4 || got[1] == want[1] || got[0] != want[0] || got[2] != want[2] || got[3] != want[3] {
+		t.Errorf("expected only phrase 2 of %q replaced, got %q", want, got)
+	}
+	if !strings.Contains(errOut, "replaced 1 phrase found in "+path) {
+		t.Errorf("rejection not reported: %q", errOut)
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright 2026 Timothy Ham
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/timothyham/dicewords"
+)
+
+const rollHelp = `
+usage: dicewords roll [options]
+
+Roll dice for one passphrase and show the rolls for each word, so the phrase
+can be checked against the printed list.
+
+options:
+-w
+    Number of words. Default is 6.
+` + dictHelp
+
+func runRoll(args []string) error {
+	fs := newFlagSet("roll", rollHelp)
+	numWords := fs.Int("w", 6, "Number of words")
+	var df dictFlags
+	df.add(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+	dict, err := df.dict()
+	if err != nil {
+		return err
+	}
+	if *numWords < 1 {
+		return usagef("-w must be at least 1")
+	}
+
+	for i := 0; i < *numWords; i++ {
+		rolls, err := dicewords.Roll(dict)
+		if err != nil {
+			return err
+		}
+		word, err := dicewords.Lookup(rolls, dict)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d %s\n", rolls, word)
+	}
+	return nil
+}
+
+const lookupHelp = `
+usage: dicewords lookup [options] rolls-or-word...
+
+Show the word for each roll, like 11111, or the rolls for each word. Useful
+with real dice.
+
+options:
+` + dictHelp
+
+func runLookup(args []string) error {
+	fs := newFlagSet("lookup", lookupHelp)
+	var df dictFlags
+	df.add(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	dict, err := df.dict()
+	if err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return usagef("nothing to look up")
+	}
+
+	failed := false
+	for _, arg := range fs.Args() {
+		if rolls, err := strconv.Atoi(arg); err == nil {
+			word, err := dicewords.Lookup(rolls, dict)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: not a roll in the %s list\n", arg, dict)
+				failed = true
+				continue
+			}
+			fmt.Printf("%d %s\n", rolls, word)
+			continue
+		}
+		rolls, ok := dicewords.FindWord(strings.ToLower(arg), dict)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "%s: not in the %s list\n", arg, dict)
+			failed = true
+			continue
+		}
+		fmt.Printf("%d %s\n", rolls, strings.ToLower(arg))
+	}
+	if failed {
+		return errors.New("some lookups failed")
+	}
+	return nil
+}
+
+const checkHelp = `
+usage: dicewords check [options] [word...]
+
+Check that every word of a passphrase is in the list, and show its strength.
+Without arguments the phrase is read from standard input, which keeps it out
+of the shell history. Words typed without a space between them are split
+apart. Only the positions of unknown words are shown, and how many words of
+the list are close to each: the word with the same first 3 letters for
+-short2, then words one or two typos away. No word of the phrase appears
+unless -show is given.
+
+options:
+` + dictHelp + `-n
+    Most suggestions for each unknown word, 0 for none. Default is 3.
+-show
+    Print the suggested words. The closest one is usually the word that was
+    meant, so this shows that part of the phrase on screen.
+`
+
+func runCheck(args []string) error {
+	fs := newFlagSet("check", checkHelp)
+	numSuggestions := fs.Int("n", 3, "Most suggestions per unknown word")
+	show := fs.Bool("show", false, "Print the suggested words")
+	var df dictFlags
+	df.add(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	dict, err := df.dict()
+	if err != nil {
+		return err
+	}
+
+	phrase := strings.Join(fs.Args(), " ")
+	if fs.NArg() == 0 {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("reading phrase: %v", err)
+		}
+		phrase = line
+	}
+	checks := dicewords.CheckPhrase(phrase, dict, *numSuggestions)
+	if len(checks) == 0 {
+		return usagef("no phrase to check")
+	}
+
+	if *show {
+		fmt.Fprintln(os.Stderr, "dicewords: -show prints suggested words, which may be words of the phrase")
+	}
+	bad := writeChecks(os.Stdout, checks, dict, *show)
+	if bad > 0 {
+		return fmt.Errorf("%d of %d words not in the list", bad, len(checks))
+	}
+	fmt.Printf("ok: %d words, %.1f bits\n", len(checks), dicewords.EstimateBits(len(checks), dict))
+	return nil
+}
+
+// writeChecks writes a line for each unknown word of checks and returns how
+// many there are. The suggestions themselves are only written if show is
+// set.
+func writeChecks(w io.Writer, checks []dicewords.WordCheck, dict dicewords.Dictionary, show bool) int {
+	bad := 0
+	for i, c := range checks {
+		if c.Known {
+			continue
+		}
+		bad++
+		switch {
+		case len(c.Suggestions) == 0:
+			fmt.Fprintf(w, "word %d is not in the %s list\n", i+1, dict)
+		case show:
+			fmt.Fprintf(w, "word %d is not in the %s list, maybe: %s\n", i+1, dict, strings.Join(c.Suggestions, ", "))
+		case len(c.Suggestions) == 1:
+			fmt.Fprintf(w, "word %d is not in the %s list, 1 close word (-show to see it)\n", i+1, dict)
+		default:
+			fmt.Fprintf(w, "word %d is not in the %s list, %d close words (-show to see them)\n", i+1, dict, len(c.Suggestions))
+		}
+	}
+	return bad
+}
+
+const expandHelp = `
+usage: dicewords expand [compact]
+
+Turn a compact password from dicewords -short2 -compact back into the words
+of the phrase. Case and spaces don't matter. Without an argument
+the password is read from standard input, which keeps it out of the shell
+history.
+
+options:
+`
+
+func runExpand(args []string) error {
+	fs := newFlagSet("expand", expandHelp)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	compact := strings.Join(fs.Args(), "")
+	if fs.NArg() == 0 {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("reading password: %v", err)
+		}
+		compact = strings.TrimSpace(line)
+	}
+	if compact == "" {
+		return usagef("no password to expand")
+	}
+
+	words, err := dicewords.Expand(compact, dicewords.Short2)
+	if err != nil {
+		return err
+	}
+	fmt.Println(strings.Join(words, " "))
+	return nil
+}
+
+const listsHelp = `
+usage: dicewords lists
+
+Show the word lists and their strength per word.
+
+options:
+`
+
+func runLists(args []string) error {
+	fs := newFlagSet("lists", listsHelp)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+
+	fmt.Printf("%-8s %-8s %6s %5s %10s\n", "list", "flag", "words", "dice", "bits/word")
+	for _, d := range []struct {
+		dict dicewords.Dictionary
+		flag string
+	}{
+		{dicewords.Large, ""},
+		{dicewords.Short, "-short"},
+		{dicewords.Short2, "-short2"},
+	} {
+		l := dicewords.List(d.dict)
+		fmt.Printf("%-8s %-8s %6d %5d %10.1f\n", d.dict, d.flag, l.Len(), l.Dice(), dicewords.EstimateBits(1, d.dict))
+	}
+	return nil
+}
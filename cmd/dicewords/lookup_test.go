@@ -0,0 +1,42 @@
+// Copyright 2026 Timothy Ham
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/timothyham/dicewords"
+)
+
+func TestWriteChecks(t *testing.T) {
+	// "tabel" is a typo of cable; "qqqqqqqq" is close to nothing
+	checks := dicewords.CheckPhrase("acid tabel qqqqqqqq", dicewords.Large, 3)
+
+	var b bytes.Buffer
+	if bad := writeChecks(&b, checks, dicewords.Large, false); bad != 2 {
+		t.Errorf("expected 2 unknown words, got %d", bad)
+	}
+	out := b.String()
+	for _, c := range checks {
+		for _, s := range c.Suggestions {
+			if strings.Contains(out, s) {
+				t.Errorf("suggestion %q shown without -show:\n%s", s, out)
+			}
+		}
+	}
+	if strings.Contains(out, "acid") || strings.Contains(out, "tabel") {
+		t.Errorf("phrase shown:\n%s", out)
+	}
+	if !strings.Contains(out, "word 2 is not in the large list, ") || !strings.Contains(out, "-show") ||
+		!strings.Contains(out, "word 3 is not in the large list\n") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	b.Reset()
+	writeChecks(&b, checks, dicewords.Large, true)
+	if !strings.Contains(b.String(), "word 2 is not in the large list, maybe: "+strings.Join(checks[1].Suggestions, ", ")) ||
+		len(checks[1].Suggestions) == 0 {
+		t.Errorf("unexpected output with -show:\n%s", b.String())
+	}
+}
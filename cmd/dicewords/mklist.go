@@ -0,0 +1,141 @@
+// Copyright 2026 Timothy Ham
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/timothyham/dicewords"
+)
+
+const mklistHelp = `
+usage: dicewords mklist [options] file...
+
+Make a diceware list from the most frequent words of a text corpus, or of a
+frequency file with -freq. The list is sorted and numbered with its rolls
+like the EFF lists, so it loads back with analyze-list and the other
+commands that take a list file. A file of - is standard input.
+
+options:
+-freq
+    Files have a word and its count on each line, not text.
+-dice
+    4 for 1296 words, 5 for 7776 words. Default is 5.
+-min
+    Fewest letters in a word. Default is 3.
+-max
+    Most letters in a word. Default is 9.
+-chars
+    Letters words may use. Default is a to z.
+-blocklist
+    File of words, one per line, never to use.
+-prefix
+    Make the first this many letters of every word unique, like the EFF
+    short list 2 does with 3.
+-prefix-free
+    Drop words that start other words, so phrases typed without spaces can
+    be read only one way.
+-out
+    Write the list to this file instead of standard output.
+`
+
+func runMklist(args []string) error {
+	fs := newFlagSet("mklist", mklistHelp)
+	freq := fs.Bool("freq", false, "Files are word frequencies")
+	var opts dicewords.ListOptions
+	fs.IntVar(&opts.Dice, "dice", 5, "Dice per word")
+	fs.IntVar(&opts.MinLength, "min", 3, "Fewest letters in a word")
+	fs.IntVar(&opts.MaxLength, "max", 9, "Most letters in a word")
+	fs.StringVar(&opts.Chars, "chars", "abcdefghijklmnopqrstuvwxyz", "Letters words may use")
+	fs.IntVar(&opts.UniquePrefix, "prefix", 0, "Unique prefix length")
+	fs.BoolVar(&opts.PrefixFree, "prefix-free", false, "Drop words that start other words")
+	blocklist := fs.String("blocklist", "", "File of words never to use")
+	out := fs.String("out", "", "Output file")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return usagef("no corpus or frequency file")
+	}
+	if opts.Dice != 4 && opts.Dice != 5 {
+		return usagef("-dice must be 4 or 5")
+	}
+	if opts.MinLength < 1 || opts.MaxLength < opts.MinLength {
+		return usagef("bad -min %d and -max %d", opts.MinLength, opts.MaxLength)
+	}
+
+	if *blocklist != "" {
+		data, err := os.ReadFile(*blocklist)
+		if err != nil {
+			return err
+		}
+		opts.Blocklist = strings.Fields(string(data))
+	}
+
+	counts := make(map[string]int)
+	for _, path := range fs.Args() {
+		c, err := readCounts(path, *freq)
+		if err != nil {
+			return err
+		}
+		for w, n := range c {
+			counts[w] += n
+		}
+	}
+
+	name := "wordlist"
+	if *out != "" {
+		name = strings.TrimSuffix(filepath.Base(*out), filepath.Ext(*out))
+	}
+	l, err := dicewords.MakeList(name, counts, opts)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err = l.WriteTo(os.Stdout)
+	} else {
+		err = writeList(*out, l)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "dicewords: %d words from %d distinct in the input, %.1f bits per word\n", l.Len(), len(counts), math.Log2(float64(l.Len())))
+	return nil
+}
+
+func writeList(path string, l *dicewords.WordList) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := l.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func readCounts(path string, freq bool) (map[string]int, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	if freq {
+		c, err := dicewords.ReadFrequencies(r)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		return c, nil
+	}
+	return dicewords.CountWords(r)
+}
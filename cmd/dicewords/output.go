@@ -0,0 +1,350 @@
+// Copyright 2026 Timothy Ham
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/timothyham/dicewords"
+)
+
+const outputHelp = `-o
+    Output format: text, json, ndjson or csv. Default is text. The structured
+    formats give each phrase's words, rolls, list, bits, length and number
+    of non space characters. ndjson writes each phrase as it is made.
+`
+
+// record is one phrase as written by the structured output formats.
+type record struct {
+	Index    int      `json:"index"`
+	Phrase   string   `json:"phrase"`
+	Words    []string `json:"words"`
+	Rolls    []int    `json:"rolls"`
+	Dict     string   `json:"dict"`
+	Bits     float64  `json:"bits"`
+	Length   int      `json:"length"`
+	NumChars int      `json:"numChars"`
+	Compact  string   `json:"compact,omitempty"`
+
+	Crack []dicewords.CrackEstimate `json:"crack"`
+}
+
+func newRecord(index int, p dicewords.Phrase) record {
+	return record{
+		Index:    index,
+		Phrase:   p.Phrase,
+		Words:    p.Words,
+		Rolls:    p.Rolls,
+		Dict:     p.Dict.String(),
+		Bits:     p.Stats.NumBits,
+		Length:   p.Stats.Length,
+		NumChars: p.Stats.NumChars,
+	}
+}
+
+// appleRecord makes a record for an Apple style password, which has no
+// words or rolls.
+func appleRecord(index int, phrase string, stats dicewords.Stats, long bool) record {
+	dict := "apple"
+	if long {
+		dict = "apple-long"
+	}
+	return record{
+		Index:    index,
+		Phrase:   phrase,
+		Words:    []string{},
+		Rolls:    []int{},
+		Dict:     dict,
+		Bits:     stats.NumBits,
+		Length:   stats.Length,
+		NumChars: stats.NumChars,
+	}
+}
+
+// output writes records in one format. Each record is written as soon as
+// it is given, except for json, which is one array written by close.
+type output interface {
+	write(r record) error
+	close() error
+}
+
+// newOutput returns the output for -o format, or for the -format template
+// if one is given. Output is buffered until close, except for ndjson.
+// Crack estimates against attackers are only made for the outputs that
+// show them.
+func newOutput(w io.Writer, format, tmpl string, verbose bool, attackers []dicewords.Attacker) (output, error) {
+	bw := bufio.NewWriter(w)
+	var out output
+	switch {
+	case tmpl != "":
+		var err error
+		if out, err = newTemplateOutput(bw, tmpl); err != nil {
+			return nil, err
+		}
+	case format == "" || format == "text":
+		out = &textOutput{w: bw, verbose: verbose}
+	case format == "json":
+		out = &jsonOutput{w: bw, records: []record{}}
+	case format == "ndjson":
+		out = &ndjsonOutput{w: bw, enc: json.NewEncoder(bw)}
+	case format == "csv":
+		out = &csvOutput{w: csv.NewWriter(bw)}
+	default:
+		return nil, usagef("unknown output format %q", format)
+	}
+	// csv, and text without -v, don't show the estimates
+	if tmpl != "" || format == "json" || format == "ndjson" || verbose && (format == "" || format == "text") {
+		out = &crackOutput{output: out, attackers: attackers}
+	}
+	return &bufferedOutput{output: out, w: bw}, nil
+}
+
+// crackOutput adds crack estimates to each record.
+type crackOutput struct {
+	output
+	attackers []dicewords.Attacker
+}
+
+func (o *crackOutput) write(r record) error {
+	r.Crack = dicewords.EstimateCrack(r.Bits, o.attackers)
+	return o.output.write(r)
+}
+
+type bufferedOutput struct {
+	output
+	w *bufio.Writer
+}
+
+func (o *bufferedOutput) close() error {
+	if err := o.output.close(); err != nil {
+		return err
+	}
+	return o.w.Flush()
+}
+
+type textOutput struct {
+	w       io.Writer
+	verbose bool
+}
+
+func (o *textOutput) write(r record) error {
+	line := r.Phrase
+	if r.Compact != "" {
+		line += "\t" + r.Compact
+	}
+	if _, err := fmt.Fprintf(o.w, "%s\n", line); err != nil {
+		return err
+	}
+	if o.verbose {
+		stats := dicewords.Stats{NumBits: r.Bits, Length: r.Length, NumChars: r.NumChars}
+		if _, err := fmt.Fprintf(o.w, "    %s\n", dicewords.PrintStats(stats)); err != nil {
+			return err
+		}
+		for _, e := range r.Crack {
+			if _, err := fmt.Fprintf(o.w, "    %s\n", dicewords.PrintCrack(e)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (o *textOutput) close() error {
+	return nil
+}
+
+type jsonOutput struct {
+	w       io.Writer
+	records []record
+}
+
+func (o *jsonOutput) write(r record) error {
+	o.records = append(o.records, r)
+	return nil
+}
+
+func (o *jsonOutput) close() error {
+	enc := json.NewEncoder(o.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(o.records)
+}
+
+// ndjsonOutput flushes each record, so that readers see it straight away.
+type ndjsonOutput struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func (o *ndjsonOutput) write(r record) error {
+	if err := o.enc.Encode(r); err != nil {
+		return err
+	}
+	return o.w.Flush()
+}
+
+func (o *ndjsonOutput) close() error {
+	return nil
+}
+
+// csvOutput writes a header and then one row per record, with words and
+// rolls separated by spaces. There is a compact column if the first record
+// has a compact password.
+type csvOutput struct {
+	w             *csv.Writer
+	headerWritten bool
+	compact       bool
+}
+
+func (o *csvOutput) write(r record) error {
+	if !o.headerWritten {
+		header := []string{"index", "phrase", "words", "rolls", "dict", "bits", "length", "num_chars"}
+		o.compact = r.Compact != ""
+		if o.compact {
+			header = append(header, "compact")
+		}
+		o.w.Write(header)
+		o.headerWritten = true
+	}
+	rolls := make([]string, len(r.Rolls))
+	for i, roll := range r.Rolls {
+		rolls[i] = strconv.Itoa(roll)
+	}
+	row := []string{
+		strconv.Itoa(r.Index),
+		r.Phrase,
+		strings.Join(r.Words, " "),
+		strings.Join(rolls, " "),
+		r.Dict,
+		strconv.FormatFloat(r.Bits, 'f', 1, 64),
+		strconv.Itoa(r.Length),
+		strconv.Itoa(r.NumChars),
+	}
+	if o.compact {
+		row = append(row, r.Compact)
+	}
+	o.w.Write(row)
+	return o.w.Error()
+}
+
+func (o *csvOutput) close() error {
+	o.w.Flush()
+	return o.w.Error()
+}
+
+const formatHelp = `-format
+    Write each phrase with a Go text/template instead, followed by a newline.
+    \t and \n outside {{actions}} are a tab and a newline. The fields are
+    .Phrase, .Words, .Rolls, .Bits, .Length, .NumChars, .Dict, .Index,
+    .Crack (a list of .Attacker, .Seconds and .Cost) and .Compact (with
+    -compact), and the functions shell, json and yaml quote a value for
+    that language:
+        -format '{{.Phrase}}\t{{printf "%.1f" .Bits}}'
+        -format 'export DB_PASS={{shell .Phrase}}'
+    Can't be used with -o.
+`
+
+var templateFuncs = template.FuncMap{
+	"shell": shellQuote,
+	"json":  jsonQuote,
+	"yaml":  yamlQuote,
+	"join":  strings.Join,
+}
+
+// templateOutput executes a template for each record.
+type templateOutput struct {
+	w    io.Writer
+	tmpl *template.Template
+}
+
+var templateEscapes = strings.NewReplacer(`\\`, `\`, `\t`, "\t", `\n`, "\n")
+
+// unescapeTemplate applies templateEscapes to the text of format outside
+// {{actions}}. Actions are left alone, so that "\n" in a string in one is
+// the template's own escape.
+func unescapeTemplate(format string) string {
+	var b strings.Builder
+	for {
+		start := strings.Index(format, "{{")
+		if start < 0 {
+			b.WriteString(templateEscapes.Replace(format))
+			return b.String()
+		}
+		b.WriteString(templateEscapes.Replace(format[:start]))
+		end := actionEnd(format, start+2)
+		b.WriteString(format[start:end])
+		format = format[end:]
+	}
+}
+
+// actionEnd returns the index just past the "}}" closing the action whose
+// text starts at i, skipping over quoted strings, or len(s) if it is not
+// closed.
+func actionEnd(s string, i int) int {
+	for i < len(s) {
+		switch c := s[i]; {
+		case c == '"' || c == '\'' || c == '`':
+			i++
+			for i < len(s) && s[i] != c {
+				if s[i] == '\\' && c != '`' {
+					i++
+				}
+				i++
+			}
+			i++
+		case strings.HasPrefix(s[i:], "}}"):
+			return i + 2
+		default:
+			i++
+		}
+	}
+	return len(s)
+}
+
+func newTemplateOutput(w io.Writer, format string) (output, error) {
+	tmpl, err := template.New("format").Funcs(templateFuncs).Parse(unescapeTemplate(format) + "\n")
+	if err != nil {
+		return nil, usagef("bad -format: %v", err)
+	}
+	return &templateOutput{w: w, tmpl: tmpl}, nil
+}
+
+func (o *templateOutput) write(r record) error {
+	return o.tmpl.Execute(o.w, r)
+}
+
+func (o *templateOutput) close() error {
+	return nil
+}
+
+// shellQuote quotes v for a POSIX shell in single quotes. A single quote
+// inside ends the quoting, is escaped with a backslash and starts it again.
+func shellQuote(v any) string {
+	return "'" + strings.ReplaceAll(fmt.Sprint(v), "'", `'\''`) + "'"
+}
+
+// jsonQuote returns v as JSON: a quoted string for strings, and the usual
+// encoding for anything else.
+func jsonQuote(v any) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+// yamlQuote quotes v as a single quoted YAML scalar, in which a single quote
+// is doubled and nothing else is special. Line breaks would be folded into
+// spaces there, so a value with control characters is double quoted with
+// JSON's escapes instead, which YAML shares.
+func yamlQuote(v any) string {
+	s := fmt.Sprint(v)
+	if strings.ContainsFunc(s, unicode.IsControl) {
+		b, _ := json.Marshal(s)
+		return string(b)
+	}
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
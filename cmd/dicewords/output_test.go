@@ -0,0 +1,327 @@
+// Copyright 2026 Timothy Ham
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/timothyham/dicewords"
+)
+
+func testRecords(t *testing.T, n, numWords int) []record {
+	t.Helper()
+	var records []record
+	for i := 0; i < n; i++ {
+		p, err := dicewords.MakePhrase(numWords, dicewords.Large)
+		if err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, newRecord(i, p))
+	}
+	return records
+}
+
+func TestJSONOutput(t *testing.T) {
+	records := testRecords(t, 3, 5)
+	var b bytes.Buffer
+	out, err := newOutput(&b, "json", "", false, dicewords.DefaultAttackers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range records {
+		if err := out.write(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if b.Len() != 0 {
+		t.Errorf("json written before close: %q", b.String())
+	}
+	if err := out.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []map[string]any
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatalf("unexpected error: %v\n%s", err, b.String())
+	}
+	if len(got) != 3 {
+		t.Fatalf("unexpected %d records", len(got))
+	}
+	for _, field := range []string{"index", "phrase", "words", "rolls", "dict", "bits", "length", "numChars", "crack"} {
+		if _, ok := got[1][field]; !ok {
+			t.Errorf("no %s field in %v", field, got[1])
+		}
+	}
+	if got[1]["index"] != 1.0 || got[1]["phrase"] != records[1].Phrase || got[1]["dict"] != "large" || got[1]["bits"] != 64.6 {
+		t.Errorf("unexpected record %v", got[1])
+	}
+	if _, ok := got[1]["compact"]; ok {
+		t.Errorf("unexpected compact field in %v", got[1])
+	}
+}
+
+func TestJSONOutputLongPhrase(t *testing.T) {
+	// the bits of 80 words once overflowed to +Inf, which JSON can't hold
+	var b bytes.Buffer
+	out, err := newOutput(&b, "json", "", false, dicewords.DefaultAttackers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := out.write(testRecords(t, 1, 80)[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := out.close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestNDJSONOutput(t *testing.T) {
+	records := testRecords(t, 2, 4)
+	var b bytes.Buffer
+	out, err := newOutput(&b, "ndjson", "", false, dicewords.DefaultAttackers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range records {
+		if err := out.write(r); err != nil {
+			t.Fatal(err)
+		}
+		// each record is written as soon as it is made
+		lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+		if len(lines) != i+1 {
+			t.Fatalf("after %d records, unexpected output %q", i+1, b.String())
+		}
+		var got record
+		if err := json.Unmarshal([]byte(lines[i]), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Index != i || got.Phrase != r.Phrase || len(got.Words) != 4 || len(got.Rolls) != 4 {
+			t.Errorf("unexpected record %+v", got)
+		}
+	}
+	if err := out.close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCSVOutput(t *testing.T) {
+	records := testRecords(t, 2, 3)
+	records[0].Phrase = `a "quoted", phrase`
+	var b bytes.Buffer
+	out, err := newOutput(&b, "csv", "", false, dicewords.DefaultAttackers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range records {
+		if err := out.write(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := out.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(&b).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"index", "phrase", "words", "rolls", "dict", "bits", "length", "num_chars"}
+	if len(rows) != 3 || strings.Join(rows[0], ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected rows %q", rows)
+	}
+	r := records[1]
+	if rows[2][0] != "1" || rows[2][1] != r.Phrase || rows[2][2] != strings.Join(r.Words, " ") ||
+		len(strings.Fields(rows[2][3])) != 3 || rows[2][4] != "large" || rows[2][5] != "38.8" {
+		t.Errorf("unexpected row %q", rows[2])
+	}
+	if rows[1][1] != records[0].Phrase {
+		t.Errorf("unexpected quoting %q", rows[1][1])
+	}
+
+	records[0].Compact = "abcdef"
+	b.Reset()
+	out, _ = newOutput(&b, "csv", "", false, dicewords.DefaultAttackers())
+	out.write(records[0])
+	out.close()
+	if !strings.HasPrefix(b.String(), "index,phrase,words,rolls,dict,bits,length,num_chars,compact\n") {
+		t.Errorf("unexpected header in %q", b.String())
+	}
+}
+
+func TestNewOutputUnknown(t *testing.T) {
+	if _, err := newOutput(&bytes.Buffer{}, "xml", "", false, dicewords.DefaultAttackers()); err == nil {
+		t.Errorf("expected error, got none")
+	}
+}
+
+func TestTemplateOutput(t *testing.T) {
+	r := record{Index: 2, Phrase: "tidy cab fax", Words: []string{"tidy", "cab", "fax"}, Bits: 38.8}
+	tests := []struct {
+		format, want string
+	}{
+		{`{{.Phrase}}\t{{printf "%.1f" .Bits}}`, "tidy cab fax\t38.8\n"},
+		{`{{printf "%s\n" .Phrase}}`, "tidy cab fax\n\n"},
+		{`{{printf "%d\t%s" .Index .Phrase}}\n-`, "2\ttidy cab fax\n-\n"},
+		{"{{printf `a\\tb`}}", "a\\tb\n"},
+		{`{{printf "}}\\"}}"}}\n`, "}}\\\"}}\n\n"},
+		{`{{join .Words "-"}} C:\\dir`, "tidy-cab-fax C:\\dir\n"},
+		{`export P={{shell .Phrase}}`, "export P='tidy cab fax'\n"},
+	}
+	for _, test := range tests {
+		var b bytes.Buffer
+		out, err := newTemplateOutput(&b, test.format)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.format, err)
+			continue
+		}
+		if err := out.write(r); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.format, err)
+		}
+		if b.String() != test.want {
+			t.Errorf("%s: expected %q, got %q", test.format, test.want, b.String())
+		}
+	}
+
+	if _, err := newTemplateOutput(&bytes.Buffer{}, "{{.Phrase"); err == nil {
+		t.Errorf("expected error, got none")
+	}
+}
+
+// quoteTests are values that are special somewhere: quotes, line breaks,
+// shell expansions, backslashes and YAML's implicit types.
+var quoteTests = []string{
+	"plain words",
+	"it's",
+	`say "hi"`,
+	"two\nlines",
+	"tab\there",
+	"$HOME $(id) `id` ${x}",
+	`back\slash\n`,
+	"!event *glob? [set] ~user; a && b | c > d # e",
+	"",
+	"yes", "no", "null", "~", "true", "1e3", "0x1f", "- item", "key: value", "&anchor", "*alias", "@", "%x", "'",
+	"émoji 🎲",
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := map[string]string{
+		"plain words": "'plain words'",
+		"it's":        `'it'\''s'`,
+		"$HOME":       "'$HOME'",
+		"":            "''",
+	}
+	for in, want := range tests {
+		if got := shellQuote(in); got != want {
+			t.Errorf("shellQuote(%q) = %s, want %s", in, got, want)
+		}
+	}
+
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh")
+	}
+	for _, in := range quoteTests {
+		out, err := exec.Command(sh, "-c", "printf %s "+shellQuote(in)).Output()
+		if err != nil {
+			t.Errorf("%q: %v", in, err)
+			continue
+		}
+		if string(out) != in {
+			t.Errorf("%q: the shell read %q", in, out)
+		}
+	}
+}
+
+func TestJSONQuote(t *testing.T) {
+	for _, in := range quoteTests {
+		q, err := jsonQuote(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got string
+		if err := json.Unmarshal([]byte(q), &got); err != nil || got != in {
+			t.Errorf("jsonQuote(%q) = %s, read back as %q, %v", in, q, got, err)
+		}
+	}
+	if q, _ := jsonQuote([]string{"a", "b"}); q != `["a","b"]` {
+		t.Errorf("unexpected %s", q)
+	}
+	if q, _ := jsonQuote(64.6); q != "64.6" {
+		t.Errorf("unexpected %s", q)
+	}
+}
+
+func TestYAMLQuote(t *testing.T) {
+	tests := map[string]string{
+		"plain words":  "'plain words'",
+		"it's":         "'it''s'",
+		`say "hi"`:     `'say "hi"'`,
+		`back\slash\n`: `'back\slash\n'`,
+		"two\nlines":   `"two\nlines"`,
+		"tab\there":    `"tab\there"`,
+		"yes":          "'yes'",
+		"null":         "'null'",
+		"~":            "'~'",
+		"1e3":          "'1e3'",
+		"key: value":   "'key: value'",
+		"- item":       "'- item'",
+		"":             "''",
+	}
+	for in, want := range tests {
+		if got := yamlQuote(in); got != want {
+			t.Errorf("yamlQuote(%q) = %s, want %s", in, got, want)
+		}
+	}
+	for _, in := range quoteTests {
+		// a single quoted scalar must be one line, and a double quoted one
+		// must read back as JSON
+		q := yamlQuote(in)
+		switch {
+		case strings.HasPrefix(q, "'"):
+			if strings.ContainsAny(q, "\r\n") || !strings.HasSuffix(q, "'") {
+				t.Errorf("yamlQuote(%q) = %s", in, q)
+			}
+			if got := strings.ReplaceAll(q[1:len(q)-1], "''", "'"); got != in {
+				t.Errorf("yamlQuote(%q) = %s, read back as %q", in, q, got)
+			}
+		default:
+			var got string
+			if err := json.Unmarshal([]byte(q), &got); err != nil || got != in {
+				t.Errorf("yamlQuote(%q) = %s, read back as %q", in, q, got)
+			}
+		}
+	}
+}
+
+func TestOutputCrack(t *testing.T) {
+	attackers := []dicewords.Attacker{{Name: "slow", Rate: 1}}
+	r := testRecords(t, 1, 2)[0]
+	tests := []struct {
+		format, tmpl string
+		verbose      bool
+		want         bool
+	}{
+		{"text", "", false, false},
+		{"text", "", true, true},
+		{"csv", "", true, false},
+		{"json", "", false, true},
+		{"ndjson", "", false, true},
+		{"text", "{{range .Crack}}{{.Attacker}}{{end}}", false, true},
+	}
+	for _, test := range tests {
+		var b bytes.Buffer
+		out, err := newOutput(&b, test.format, test.tmpl, test.verbose, attackers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out.write(r)
+		out.close()
+		if got := strings.Contains(b.String(), "slow"); got != test.want {
+			t.Errorf("%s %q -v=%v: crack shown %v, want %v:\n%s", test.format, test.tmpl, test.verbose, got, test.want, b.String())
+		}
+	}
+}
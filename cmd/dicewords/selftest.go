@@ -0,0 +1,51 @@
+// Copyright 2026 Timothy Ham
+package main
+
+import (
+	"fmt"
+
+	"github.com/timothyham/dicewords"
+)
+
+const selftestHelp = `
+usage: dicewords selftest [options]
+
+Check the machine before trusting it, e.g. for a key ceremony:
++ the built-in word lists match their pinned SHA-256 digests,
++ known rolls give known words in every list (11111 is abacus, 66666 is zoom),
++ every list has one word per roll of the dice and no word twice,
++ the random source passes the repetition count and adaptive proportion
+  health tests of NIST SP 800-90B.
+Exits with status 1 if anything fails.
+
+options:
+-samples
+    Bytes of the random source to health test. Default is 1048576.
+`
+
+func runSelftest(args []string) error {
+	fs := newFlagSet("selftest", selftestHelp)
+	samples := fs.Int("samples", 1<<20, "Bytes of the random source to health test")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+
+	failed := 0
+	results := dicewords.SelfTest(*samples)
+	for _, r := range results {
+		if r.Err != nil {
+			fmt.Printf("FAIL %s: %v\n", r.Name, r.Err)
+			failed++
+			continue
+		}
+		fmt.Printf("ok   %s\n", r.Name)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d self-tests failed", failed, len(results))
+	}
+	fmt.Printf("all %d self-tests passed\n", len(results))
+	return nil
+}
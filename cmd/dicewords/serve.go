@@ -0,0 +1,70 @@
+// Copyright 2026 Timothy Ham
+package main
+
+import (
+	"github.com/timothyham/dicewords"
+	"github.com/timothyham/dicewords/web"
+)
+
+const serveHelp = `
+usage: dicewords serve [options]
+
+Serve passphrases over HTTP, FastCGI or SCGI. The response is plain text,
+JSON or HTML depending on the Accept header, or ?format=text|json|html.
+
+options:
+-b
+    Target number of bits. Default is 64 bits.
+-p 
+    Number of passphrases per request. Default is 5.
+-w
+    Number of words per passphrase. Can't be used with -b.
+` + dictHelp + `-v
+    Show additional information, including crack time estimates.
+` + attackersHelp + web.FlagsHelp
+
+func runServe(args []string) error {
+	fs := newFlagSet("serve", serveHelp)
+	numPhrases := fs.Int("p", 5, "Number of phrases per request")
+	numWords := fs.Int("w", 0, "Number of words per passphrase")
+	numBits := fs.Int("b", 64, "Number of bits to generates")
+	verbose := fs.Bool("v", false, "Print additional info")
+	var df dictFlags
+	df.add(fs)
+	var af attackersFlag
+	af.add(fs)
+	var opts web.Options
+	opts.AddFlags(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+	if err := exclusive(setFlags(fs), "w", "b"); err != nil {
+		return err
+	}
+	dict, err := df.dict()
+	if err != nil {
+		return err
+	}
+	attackers, err := af.load()
+	if err != nil {
+		return err
+	}
+
+	conf := dicewords.MakeConfig()
+	conf.Dict = dict
+	conf.NumWords = *numWords
+	conf.NumBits = *numBits
+	conf.NumPhrases = *numPhrases
+
+	h := opts.Handler(conf, *verbose)
+	h.Attackers = attackers
+	server, err := opts.Server(h)
+	if err != nil {
+		return err
+	}
+	dicewords.Logger.Info("serving", "mode", server.Mode, "addr", server.Addr)
+	return server.ListenAndServe()
+}
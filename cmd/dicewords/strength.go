@@ -0,0 +1,83 @@
+// Copyright 2026 Timothy Ham
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/timothyham/dicewords"
+)
+
+const strengthHelp = `
+usage: dicewords strength [options] [password]
+
+Estimate how guessable any password is, not only generated ones, in the
+spirit of zxcvbn: it finds EFF list words (also in l33t speak or with
+capitals), keyboard patterns, repeats, sequences and dates, and shows the
+cheapest way to guess the password, a score from 0 to 4 and advice. It works
+offline. Without an argument the password is read from standard input, which
+keeps it out of the shell history. Only the positions of the pieces found are
+shown.
+
+options:
+-v
+    Also show the text of each piece.
+-pwned
+    Also look the password up in this local copy of the Pwned Passwords
+    SHA-1 list, with lines of hash:count sorted by hash, and fail if it is
+    there.
+`
+
+func runStrength(args []string) error {
+	fs := newFlagSet("strength", strengthHelp)
+	verbose := fs.Bool("v", false, "Show the text of each piece")
+	pwned := fs.String("pwned", "", "Pwned Passwords SHA-1 file to look the password up in")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	password := strings.Join(fs.Args(), " ")
+	if fs.NArg() == 0 {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("reading password: %v", err)
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+
+	s := dicewords.EstimateStrength(password)
+	fmt.Printf("score %d of 4, about 10^%.1f guesses, %.1f bits\n", s.Score, math.Log10(s.Guesses), s.Bits)
+	for _, m := range s.Sequence {
+		fmt.Printf("    %-10s characters %d to %d, %.3g guesses", m.Pattern, m.I+1, m.J+1, m.Guesses)
+		if *verbose {
+			fmt.Printf(": %q", m.Token)
+		}
+		fmt.Println()
+	}
+	if s.Warning != "" {
+		fmt.Println(s.Warning)
+	}
+	for _, sg := range s.Suggestions {
+		fmt.Println("+ " + sg)
+	}
+
+	if *pwned == "" {
+		return nil
+	}
+	pl, err := dicewords.OpenPwnedList(*pwned)
+	if err != nil {
+		return err
+	}
+	defer pl.Close()
+	n, err := pl.Count(password)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return fmt.Errorf("the password has been seen %d times in breaches, never use it", n)
+	}
+	fmt.Println("not found in the breached passwords")
+	return nil
+}
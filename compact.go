@@ -0,0 +1,57 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Compact returns p as a compact password of the first UniquePrefix letters
+// of each word, like "aarimbzuc" for aardvark imbecile zucchini. Only the
+// short list 2 has unique prefixes. The prefixes pick the same words, so
+// the password is exactly as strong as the phrase. With caps each prefix
+// starts with a capital, which shows where words start but adds no
+// strength.
+func Compact(p Phrase, caps bool) (string, error) {
+	l := List(p.Dict)
+	n := l.UniquePrefix()
+	if n == 0 {
+		return "", fmt.Errorf("the %v list has no unique prefixes, use %v", p.Dict, Short2)
+	}
+	var b strings.Builder
+	for _, w := range p.Words {
+		if _, ok := l.Find(w); !ok {
+			return "", fmt.Errorf("%q is not in the %v list", w, p.Dict)
+		}
+		prefix := w[:n]
+		if caps {
+			prefix = strings.ToUpper(prefix[:1]) + prefix[1:]
+		}
+		b.WriteString(prefix)
+	}
+	return b.String(), nil
+}
+
+// Expand turns a compact password made by Compact back into the words of
+// dict. Case and spaces are ignored. Hyphens are not, since yo-yo starts
+// with yo-.
+func Expand(compact string, dict Dictionary) ([]string, error) {
+	l := List(dict)
+	n := l.UniquePrefix()
+	if n == 0 {
+		return nil, fmt.Errorf("the %v list has no unique prefixes, use %v", dict, Short2)
+	}
+	s := strings.ToLower(strings.Join(strings.Fields(compact), ""))
+	if s == "" || len(s)%n != 0 {
+		return nil, fmt.Errorf("%d letters is not a whole number of %d letter prefixes", len(s), n)
+	}
+	words := make([]string, 0, len(s)/n)
+	for i := 0; i < len(s); i += n {
+		e, ok := l.FindPrefix(s[i : i+n])
+		if !ok {
+			return nil, fmt.Errorf("word %d: no word in the %v list starts with those letters", i/n+1, dict)
+		}
+		words = append(words, e.Word)
+	}
+	return words, nil
+}
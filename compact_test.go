@@ -0,0 +1,69 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompact(t *testing.T) {
+	p := Phrase{Words: []string{"aardvark", "imbecile", "zucchini"}, Dict: Short2}
+	if c, err := Compact(p, false); err != nil || c != "aarimbzuc" {
+		t.Errorf("unexpected %q %v", c, err)
+	}
+	if c, err := Compact(p, true); err != nil || c != "AarImbZuc" {
+		t.Errorf("unexpected %q %v", c, err)
+	}
+	if _, err := Compact(Phrase{Words: []string{"abacus"}, Dict: Large}, false); err == nil {
+		t.Errorf("expected error for the large list")
+	}
+	if _, err := Compact(Phrase{Words: []string{"abacus"}, Dict: Short2}, false); err == nil {
+		t.Errorf("expected error for a word not in the list")
+	}
+}
+
+func TestExpand(t *testing.T) {
+	words, err := Expand("AarImb zuc", Short2)
+	if err != nil || !reflect.DeepEqual(words, []string{"aardvark", "imbecile", "zucchini"}) {
+		t.Errorf("unexpected %v %v", words, err)
+	}
+	words, err = Expand("yo-aar", Short2)
+	if err != nil || !reflect.DeepEqual(words, []string{"yo-yo", "aardvark"}) {
+		t.Errorf("unexpected %v %v", words, err)
+	}
+	for _, bad := range []string{"", "aarim", "aarzzz"} {
+		if _, err := Expand(bad, Short2); err == nil {
+			t.Errorf("%q: expected error", bad)
+		}
+	}
+
+	for i := 0; i < 20; i++ {
+		p, err := MakePhrase(6, Short2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		c, err := Compact(p, i%2 == 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		words, err := Expand(c, Short2)
+		if err != nil || !reflect.DeepEqual(words, p.Words) {
+			t.Errorf("%q expanded to %v %v, expected %v", c, words, err, p.Words)
+		}
+	}
+}
+
+func TestUniquePrefix(t *testing.T) {
+	if n := List(Short2).UniquePrefix(); n != 3 {
+		t.Errorf("expected 3, got %d", n)
+	}
+	if n := List(Large).UniquePrefix(); n != 0 {
+		t.Errorf("expected 0, got %d", n)
+	}
+	if e, ok := List(Short2).FindPrefix("zuc"); !ok || e.Rolls != 6666 {
+		t.Errorf("unexpected %v %v", e, ok)
+	}
+	if _, ok := List(Short2).FindPrefix("zucc"); ok {
+		t.Errorf("expected no entry for a long prefix")
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// Attacker is a way of guessing passwords and how fast it goes.
+type Attacker struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Rate is guesses per second.
+	Rate float64 `json:"rate"`
+	// CostPerHour is what the hardware costs to rent, in dollars, or 0 for
+	// attacks that cost next to nothing, like guessing online.
+	CostPerHour float64 `json:"costPerHour"`
+}
+
+// DefaultAttackers returns the built in attacker models, a new slice each
+// time. The offline rates are for a rig of 8 RTX 4090 class GPUs running
+// hashcat, about $6 an hour to rent: 288 billion NTLM or 164 billion MD5
+// hashes a second per GPU, 5,750 bcrypt hashes at cost 10 and roughly
+// 1,000 Argon2id hashes at 64 MiB and 3 passes.
+func DefaultAttackers() []Attacker {
+	return []Attacker{
+		{"online-throttled", "online, throttled to 100 guesses an hour", 100.0 / 3600, 0},
+		{"online", "online, not throttled", 10, 0},
+		{"ntlm", "offline, NTLM fast hash, 8 GPUs", 8 * 288e9, 6},
+		{"md5", "offline, MD5 fast hash, 8 GPUs", 8 * 164e9, 6},
+		{"bcrypt", "offline, bcrypt cost 10 slow hash, 8 GPUs", 8 * 5750, 6},
+		{"argon2id", "offline, Argon2id 64 MiB slow hash, 8 GPUs", 8 * 1000, 6},
+	}
+}
+
+// ReadAttackers reads a JSON array of attackers, such as
+// [{"name": "sha256", "description": "offline, SHA-256, 1 GPU", "rate": 2.2e10, "costPerHour": 0.75}].
+func ReadAttackers(r io.Reader) ([]Attacker, error) {
+	var attackers []Attacker
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&attackers); err != nil {
+		return nil, fmt.Errorf("reading attackers: %v", err)
+	}
+	for _, a := range attackers {
+		if a.Name == "" || !(a.Rate > 0) || a.CostPerHour < 0 {
+			return nil, fmt.Errorf("attacker %q needs a name, a rate above 0 and a cost of at least 0", a.Name)
+		}
+	}
+	return attackers, nil
+}
+
+// LoadAttackers reads the named file with ReadAttackers.
+func LoadAttackers(path string) ([]Attacker, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadAttackers(f)
+}
+
+// FindAttacker returns the attacker of attackers called name.
+func FindAttacker(attackers []Attacker, name string) (Attacker, bool) {
+	for _, a := range attackers {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Attacker{}, false
+}
+
+// CrackEstimate is how long an attacker takes on average to guess a
+// password, and what it costs.
+type CrackEstimate struct {
+	Attacker string  `json:"attacker"`
+	Seconds  float64 `json:"seconds"`
+	Cost     float64 `json:"cost"`
+}
+
+// EstimateCrack estimates cracking a password of bits bits, such as
+// Stats.NumBits, for each of attackers. On average half of the 2^bits
+// guesses are needed. Times and costs too large for a float64 are
+// math.MaxFloat64, since JSON has no infinity.
+func EstimateCrack(bits float64, attackers []Attacker) []CrackEstimate {
+	out := make([]CrackEstimate, len(attackers))
+	for i, a := range attackers {
+		seconds := min(math.Exp2(bits-1)/a.Rate, math.MaxFloat64)
+		cost := min(seconds/3600*a.CostPerHour, math.MaxFloat64)
+		out[i] = CrackEstimate{Attacker: a.Name, Seconds: seconds, Cost: cost}
+	}
+	return out
+}
+
+// BitsToResist returns the bits a password needs for attacker to take on
+// average at least seconds to guess it.
+func BitsToResist(seconds float64, attacker Attacker) float64 {
+	return math.Log2(seconds) + math.Log2(attacker.Rate) + 1
+}
+
+// PrintCrack describes e, like "ntlm: 3.1e+05 years, $1.6e+10".
+func PrintCrack(e CrackEstimate) string {
+	if e.Cost == 0 {
+		return fmt.Sprintf("%s: %s", e.Attacker, FormatSeconds(e.Seconds))
+	}
+	return fmt.Sprintf("%s: %s, %s", e.Attacker, FormatSeconds(e.Seconds), formatDollars(e.Cost))
+}
+
+// SecondsPerYear is the length of an average year.
+const SecondsPerYear = 365.25 * 24 * 3600
+
+// FormatSeconds writes a duration that may be far longer than
+// time.Duration holds, in the largest unit that fits.
+func FormatSeconds(s float64) string {
+	switch {
+	case s < 1:
+		return "under a second"
+	case s < 60:
+		return plural(s, "second")
+	case s < 3600:
+		return plural(s/60, "minute")
+	case s < 24*3600:
+		return plural(s/3600, "hour")
+	case s < SecondsPerYear:
+		return plural(s/(24*3600), "day")
+	case s < 1e6*SecondsPerYear:
+		return plural(s/SecondsPerYear, "year")
+	}
+	return fmt.Sprintf("%.1e years", s/SecondsPerYear)
+}
+
+func plural(n float64, unit string) string {
+	n = math.Floor(n)
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%.0f %ss", n, unit)
+}
+
+func formatDollars(d float64) string {
+	switch {
+	case d < 0.01:
+		return "under $0.01"
+	case d < 1e6:
+		return fmt.Sprintf("$%.2f", d)
+	}
+	return fmt.Sprintf("$%.1e", d)
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestEstimateCrack(t *testing.T) {
+	attackers := []Attacker{
+		{Name: "slow", Rate: 1000, CostPerHour: 3.6},
+		{Name: "online", Rate: 10},
+	}
+	est := EstimateCrack(21, attackers)
+	if len(est) != 2 {
+		t.Fatalf("unexpected estimates %+v", est)
+	}
+	// 2^20 guesses on average
+	if est[0].Attacker != "slow" || math.Abs(est[0].Seconds-1048.576) > 1e-9 || math.Abs(est[0].Cost-1.048576) > 1e-9 {
+		t.Errorf("unexpected estimate %+v", est[0])
+	}
+	if est[1].Cost != 0 || math.Abs(est[1].Seconds-104857.6) > 1e-6 {
+		t.Errorf("unexpected estimate %+v", est[1])
+	}
+
+	if bits := BitsToResist(est[0].Seconds, attackers[0]); math.Abs(bits-21) > 1e-9 {
+		t.Errorf("expected 21 bits, got %v", bits)
+	}
+
+	// 2^1033 guesses are more than a float64 holds
+	for _, e := range EstimateCrack(1034, attackers) {
+		if math.IsInf(e.Seconds, 0) || math.IsInf(e.Cost, 0) {
+			t.Errorf("unexpected estimate %+v", e)
+		}
+	}
+
+	if _, ok := FindAttacker(attackers, "online"); !ok {
+		t.Errorf("online not found")
+	}
+	if _, ok := FindAttacker(attackers, "nobody"); ok {
+		t.Errorf("nobody found")
+	}
+
+	// the defaults can't be changed through a returned slice
+	d := DefaultAttackers()
+	d[0].Rate = 1e20
+	if DefaultAttackers()[0].Rate == 1e20 {
+		t.Errorf("defaults changed")
+	}
+}
+
+func TestReadAttackers(t *testing.T) {
+	attackers, err := ReadAttackers(strings.NewReader(`[{"name": "sha256", "description": "1 GPU", "rate": 2.2e10, "costPerHour": 0.75}]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(attackers) != 1 || attackers[0] != (Attacker{"sha256", "1 GPU", 2.2e10, 0.75}) {
+		t.Errorf("unexpected attackers %+v", attackers)
+	}
+
+	for _, bad := range []string{
+		`{"name": "x", "rate": 1}`,
+		`[{"name": "x", "rate": 0}]`,
+		`[{"rate": 1}]`,
+		`[{"name": "x", "rate": 1, "speed": 2}]`,
+	} {
+		if _, err := ReadAttackers(strings.NewReader(bad)); err == nil {
+			t.Errorf("expected error for %s", bad)
+		}
+	}
+}
+
+func TestFormatSeconds(t *testing.T) {
+	for _, tc := range []struct {
+		seconds float64
+		want    string
+	}{
+		{0.5, "under a second"},
+		{1, "1 second"},
+		{90, "1 minute"},
+		{3 * 3600, "3 hours"},
+		{400 * 24 * 3600, "1 year"},
+		{2e15 * SecondsPerYear, "2.0e+15 years"},
+	} {
+		if got := FormatSeconds(tc.seconds); got != tc.want {
+			t.Errorf("FormatSeconds(%v) = %q, want %q", tc.seconds, got, tc.want)
+		}
+	}
+
+	got := PrintCrack(CrackEstimate{Attacker: "bcrypt", Seconds: 7200, Cost: 12})
+	if got != "bcrypt: 2 hours, $12.00" {
+		t.Errorf("unexpected %q", got)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"crypto/rand"
+	"errors"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ErrNotDecodable is returned when a phrase without separators is asked
+// for from a list where two word sequences can run together into the same
+// string.
+var ErrNotDecodable = errors.New("the word list is not uniquely decodable, so phrases need separators")
+
+// UniquelyDecodable reports whether any string made by running words
+// together splits back into words only one way, using the Sardinas-Patterson
+// test. Prefix-free lists, like the EFF lists, always are.
+func UniquelyDecodable(words []string) bool {
+	code := make(map[string]bool, len(words))
+	for _, w := range words {
+		if code[w] {
+			return false
+		}
+		code[w] = true
+	}
+	sorted := make([]string, 0, len(code))
+	for w := range code {
+		sorted = append(sorted, w)
+	}
+	sort.Strings(sorted)
+
+	// dangling suffixes of one word after another that starts it
+	cur := make(map[string]bool)
+	for i, w := range sorted {
+		for _, longer := range sorted[i+1:] {
+			if !strings.HasPrefix(longer, w) {
+				break
+			}
+			cur[longer[len(w):]] = true
+		}
+	}
+
+	seen := make(map[string]bool)
+	for len(cur) > 0 {
+		next := make(map[string]bool)
+		for s := range cur {
+			if code[s] {
+				return false
+			}
+			seen[s] = true
+		}
+		for s := range cur {
+			// words that start with s leave a suffix
+			i := sort.SearchStrings(sorted, s)
+			for ; i < len(sorted) && strings.HasPrefix(sorted[i], s); i++ {
+				if w := sorted[i]; w != s {
+					next[w[len(s):]] = true
+				}
+			}
+			// and so do words that start s
+			for j := 1; j < len(s); j++ {
+				if code[s[:j]] {
+					next[s[j:]] = true
+				}
+			}
+		}
+		for s := range next {
+			if seen[s] {
+				delete(next, s)
+			}
+		}
+		cur = next
+	}
+	return true
+}
+
+// SeparatorLoss estimates the bits lost by running numWords words together
+// without separators, from samples random phrases. It is the average of
+// log2 of the ways each joined phrase splits into numWords words, which is
+// what an attacker who knows the list and the number of words is spared.
+// It is 0 for uniquely decodable lists.
+func SeparatorLoss(words []string, numWords, samples int) (float64, error) {
+	if UniquelyDecodable(words) || samples < 1 {
+		return 0, nil
+	}
+	code := make(map[string]bool, len(words))
+	var lengths []int
+	for _, w := range words {
+		if !code[w] {
+			lengths = append(lengths, len(w))
+		}
+		code[w] = true
+	}
+	slices.Sort(lengths)
+	lengths = slices.Compact(lengths)
+
+	s := newSource(rand.Reader, sourceBlock)
+	var b strings.Builder
+	total := 0.0
+	for i := 0; i < samples; i++ {
+		b.Reset()
+		for j := 0; j < numWords; j++ {
+			idx, err := s.intn(len(words))
+			if err != nil {
+				return 0, err
+			}
+			b.WriteString(words[idx])
+		}
+		total += math.Log2(float64(countSplits(b.String(), numWords, code, lengths)))
+	}
+	return total / float64(samples), nil
+}
+
+// countSplits counts the ways to split s into exactly n words of code.
+func countSplits(s string, n int, code map[string]bool, lengths []int) float64 {
+	// ways[i] is the number of ways to split s[:i] into the words so far
+	ways := make([]float64, len(s)+1)
+	ways[0] = 1
+	for k := 0; k < n; k++ {
+		next := make([]float64, len(s)+1)
+		for i, w := range ways {
+			if w == 0 {
+				continue
+			}
+			for _, l := range lengths {
+				if i+l <= len(s) && code[s[i:i+l]] {
+					next[i+l] += w
+				}
+			}
+		}
+		ways = next
+	}
+	return ways[len(s)]
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestUniquelyDecodable(t *testing.T) {
+	for _, test := range []struct {
+		words []string
+		want  bool
+	}{
+		{[]string{"abacus", "tee", "aba", "custee"}, false},
+		{[]string{"a", "ab", "b"}, false},
+		{[]string{"a", "a"}, false},
+		// not prefix-free, but still decodable
+		{[]string{"0", "01", "11"}, true},
+		{[]string{"abacus", "tee", "sun"}, true},
+	} {
+		if got := UniquelyDecodable(test.words); got != test.want {
+			t.Errorf("%v: expected %v, got %v", test.words, test.want, got)
+		}
+	}
+
+	for _, dict := range []Dictionary{Large, Short, Short2} {
+		if !List(dict).UniquelyDecodable() {
+			t.Errorf("%v: expected uniquely decodable", dict)
+		}
+	}
+}
+
+func TestSeparatorLoss(t *testing.T) {
+	// of the 16 pairs only abacus+tee and aba+custee share a string, so
+	// 2/16 of phrases lose one bit
+	loss, err := SeparatorLoss([]string{"abacus", "tee", "aba", "custee"}, 2, 20000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(loss-0.125) > 0.02 {
+		t.Errorf("expected about 0.125 bits, got %v", loss)
+	}
+
+	loss, err = SeparatorLoss(List(Short).Words(), 6, 1000)
+	if err != nil || loss != 0 {
+		t.Errorf("expected no loss, got %v %v", loss, err)
+	}
+
+	if n := countSplits("abacustee", 2, map[string]bool{"abacus": true, "tee": true, "aba": true, "custee": true}, []int{3, 6}); n != 2 {
+		t.Errorf("expected 2 splits, got %v", n)
+	}
+}
+
+func TestNoSeparator(t *testing.T) {
+	conf := MakeConfig()
+	conf.NumWords = 4
+	conf.NoSeparator = true
+	err := Stream(conf, func(i int, p Phrase) error {
+		if p.Phrase != strings.Join(p.Words, "") || p.Stats.Length != p.Stats.NumChars {
+			t.Errorf("unexpected phrase %q, stats %v", p.Phrase, p.Stats)
+		}
+		if p.Stats.NumBits != EstimateBits(4, Large) {
+			t.Errorf("unexpected bits %v", p.Stats.NumBits)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
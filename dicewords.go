@@ -3,13 +3,14 @@ package dicewords
 
 import (
 	"crypto/rand"
-	"errors"
 	"fmt"
+	"io"
 	"math"
-	"math/big"
 	"strings"
 )
 
+// Debug traces rolls, list indexes and words to Logger at debug level. Turn
+// it on with EnableDebug, never in production.
 var Debug bool
 var NumWords int
 var VersionString string
@@ -26,12 +27,53 @@ const (
 	Short2
 )
 
+func (d Dictionary) String() string {
+	switch d {
+	case Large:
+		return "large"
+	case Short:
+		return "short"
+	case Short2:
+		return "short2"
+	}
+	return fmt.Sprintf("Dictionary(%d)", int(d))
+}
+
+// ParseDictionary returns the dictionary named by String.
+func ParseDictionary(name string) (Dictionary, error) {
+	for _, d := range []Dictionary{Large, Short, Short2} {
+		if strings.EqualFold(name, d.String()) {
+			return d, nil
+		}
+	}
+	return Large, fmt.Errorf("unknown dictionary %q", name)
+}
+
 type Config struct {
 	NumWords   int
 	NumBits    int
 	NumPhrases int
 	Dict       Dictionary
 	AppleStyle bool
+
+	// Workers is how many goroutines Phrases and Stream generate with.
+	// Zero means one. There are never more than NumPhrases, or four per
+	// CPU.
+	Workers int
+	// Seed, if set, makes Phrases and Stream reproducible: phrase i comes
+	// from a ChaCha8 stream keyed by Seed and i, whatever the number of
+	// workers. Anyone with the seed has every phrase, so it is only for
+	// test fixtures and the like.
+	Seed []byte
+	// NoSeparator runs the words of Phrases and Stream together without
+	// spaces. It needs a uniquely decodable list, which the EFF lists are;
+	// otherwise Phrases fails with ErrNotDecodable.
+	NoSeparator bool
+	// Reject, if set, is asked about every phrase Phrases and Stream make,
+	// and rejected phrases are replaced by new ones, such as phrases found
+	// in a PwnedList. With Workers above one it is called from several
+	// goroutines at once.
+	Reject func(p Phrase) (bool, error)
 }
 
 func MakeConfig() Config {
@@ -42,20 +84,7 @@ func MakeWords(config Config) ([]string, []Stats) {
 	var out []string
 	var statOut []Stats
 
-	if config.NumWords == 0 {
-		if config.NumBits == 0 {
-			config.NumWords = 5
-		} else {
-			// use numBits to determine numWords
-			for i := 1; i < 20; i++ {
-				estBits := EstimateBits(i, config.Dict)
-				if estBits >= float64(config.NumBits) {
-					config.NumWords = i
-					break
-				}
-			}
-		}
-	}
+	config.NumWords = config.Words()
 	for i := 0; i < config.NumPhrases; i++ {
 		phrase, stats := GetPhrase(config.NumWords, config.Dict)
 		out = append(out, phrase)
@@ -64,59 +93,53 @@ func MakeWords(config Config) ([]string, []Stats) {
 	return out, statOut
 }
 
+// MaxWords is the most words Config.Words picks for a bit target and
+// Advise recommends, and the web handler's default limit per phrase.
+const MaxWords = 20
+
+// Words returns the number of words per phrase: NumWords if set, otherwise
+// enough words for NumBits, otherwise 5. It is 0 if NumBits needs more than
+// MaxWords words.
+func (config Config) Words() int {
+	if config.NumWords != 0 {
+		return config.NumWords
+	}
+	if config.NumBits == 0 {
+		return 5
+	}
+	// use numBits to determine numWords
+	for i := 1; i <= MaxWords; i++ {
+		estBits := EstimateBits(i, config.Dict)
+		if estBits >= float64(config.NumBits) {
+			return i
+		}
+	}
+	return 0
+}
+
 func init() {
-	EFFLargeWordList = strings.Split(EFFLargeWordListRaw, "\n")
-	EFFLargeWordList = EFFLargeWordList[1 : len(EFFLargeWordList)-1]
+	listsErr = VerifyLists()
 
-	EFFShortWordList = strings.Split(EFFShortWordListRaw, "\n")
-	EFFShortWordList = EFFShortWordList[1 : len(EFFShortWordList)-1]
+	EFFLargeWordList = strings.Split(strings.TrimSuffix(EFFLargeWordListRaw, "\n"), "\n")
+	EFFShortWordList = strings.Split(strings.TrimSuffix(EFFShortWordListRaw, "\n"), "\n")
+	EFFShortWordUniqPrefix = strings.Split(strings.TrimSuffix(EFFShortWordUniqPrefixRaw, "\n"), "\n")
 
-	EFFShortWordUniqPrefix = strings.Split(EFFShortWordUniqPrefixRaw, "\n")
-	EFFShortWordUniqPrefix = EFFShortWordUniqPrefix[1 : len(EFFShortWordUniqPrefix)-1]
+	largeList = builtinList("eff_large_wordlist", EFFLargeWordList, 5)
+	shortList = builtinList("eff_short_wordlist_1", EFFShortWordList, 4)
+	short2List = builtinList("eff_short_wordlist_2_0", EFFShortWordUniqPrefix, 4)
 }
 
 // GetLargeWord needs 5 digit rolls
 func GetLargeWord(rolls int) (string, error) {
-	return getWord(EFFLargeWordList, rolls, 11111)
+	return largeList.Lookup(rolls)
 }
 
 func GetShortWord(rolls int) (string, error) {
-	return getWord(EFFShortWordList, rolls, 1111)
+	return shortList.Lookup(rolls)
 }
 
 func GetShortUniqueWord(rolls int) (string, error) {
-	return getWord(EFFShortWordUniqPrefix, rolls, 1111)
-}
-
-func getWord(list []string, rolls, smallest int) (string, error) {
-	if rolls < smallest {
-		return "", errors.New(fmt.Sprintf("Roll smaller than %d. Got %d\n", smallest, rolls))
-	}
-	// convert rolls into row index
-	idx := 0
-	factor := 1
-	_ = idx
-	rollsCopy := rolls
-	for {
-		digit := rolls % 10
-		if digit > 6 {
-			return "", errors.New(fmt.Sprintf("Bad roll input %d\n", rollsCopy))
-		}
-		idx += factor * (digit - 1)
-		factor = factor * 6
-		rolls = rolls / 10
-
-		if rolls == 0 {
-			break
-		}
-	}
-
-	if idx < 0 || idx > len(list)-1 {
-		return "", errors.New(fmt.Sprintf("roll outside range %d\n", rollsCopy))
-	}
-	row := list[idx]
-	fields := strings.Split(row, "\t")
-	return fields[1], nil
+	return short2List.Lookup(rolls)
 }
 
 func PrintStats(stats Stats) string {
@@ -147,56 +170,62 @@ func getStats(phrase string, dict Dictionary) Stats {
 }
 
 func GetPhrase(numWords int, dict Dictionary) (string, Stats) {
-	// dice has six sides
-	six := big.NewInt(6)
-
-	// large words = 5 rolls, short words = 4 rolls
-	count := 5
-	if dict != Large {
-		count = 4
+	p, err := MakePhrase(numWords, dict)
+	if err != nil {
+		logger().Error("making phrase", "err", err)
+		return "", Stats{}
 	}
+	return p.Phrase, p.Stats
+}
 
-	nums := make([]int, count)
-	res := ""
+// Phrase is a passphrase along with the words and rolls it was made from.
+type Phrase struct {
+	Phrase string
+	Words  []string
+	Rolls  []int
+	Dict   Dictionary
+	Stats  Stats
+}
 
-	for j := 0; j < numWords; j++ {
-		for i := 0; i < count; i++ {
-			n, err := rand.Int(rand.Reader, six)
-			if err != nil {
-				fmt.Printf("error: %v\n", err)
-				return "", Stats{}
-			}
-			nums[i] = int(n.Int64())
-		}
+// MakePhrase rolls numWords words from dict. It fails if numWords is below
+// 1 or the random source fails.
+func MakePhrase(numWords int, dict Dictionary) (Phrase, error) {
+	return MakePhraseFrom(rand.Reader, numWords, dict)
+}
 
-		factor := 1
-		rolls := 0
-		for i := count - 1; i > -1; i-- {
-			digit := nums[i] + 1
-			rolls = rolls + factor*digit
-			factor = factor * 10
-		}
-		var word string
-		var err error
-		switch dict {
-		case Large:
-			word, err = GetLargeWord(rolls)
-		case Short:
-			word, err = GetShortWord(rolls)
-		case Short2:
-			word, err = GetShortUniqueWord(rolls)
-		default:
-			word, err = GetLargeWord(rolls)
-		}
+// MakePhraseFrom is MakePhrase using random bytes from r.
+func MakePhraseFrom(r io.Reader, numWords int, dict Dictionary) (Phrase, error) {
+	return phraseFrom(newSource(r, 64), numWords, dict)
+}
 
-		if err != nil {
-			panic(err.Error())
-		}
-		res = res + word + " "
+// Roll rolls the dice for one word of dict and returns them as the list
+// writes them, e.g. 11111. Large words take 5 rolls, short words 4.
+func Roll(dict Dictionary) (int, error) {
+	return RollFrom(rand.Reader, dict)
+}
+
+// RollFrom is Roll using random bytes from r.
+func RollFrom(r io.Reader, dict Dictionary) (int, error) {
+	if listsErr != nil {
+		return 0, listsErr
+	}
+	l := List(dict)
+	idx, err := newSource(r, 4).intn(l.Len())
+	if err != nil {
+		return 0, err
 	}
+	return l.entries[idx].Rolls, nil
+}
+
+// Lookup returns the word for rolls in dict.
+func Lookup(rolls int, dict Dictionary) (string, error) {
+	return List(dict).Lookup(rolls)
+}
 
-	res = strings.TrimSpace(res)
-	return res, getStats(res, dict)
+// FindWord returns the rolls for word in dict.
+func FindWord(word string, dict Dictionary) (int, bool) {
+	e, ok := List(dict).Find(word)
+	return e.Rolls, ok
 }
 
 func EstimateBits(numWords int, dict Dictionary) float64 {
@@ -209,7 +238,9 @@ func EstimateBits(numWords int, dict Dictionary) float64 {
 	case Short2:
 		combos = 1296
 	}
-	bits := math.Log2(math.Pow(float64(combos), float64(numWords)))
+	// bits per word times words, which unlike the bits of combos^numWords
+	// can't overflow
+	bits := float64(numWords) * math.Log2(float64(combos))
 	return math.Round(bits*10) / 10
 
 	/*
@@ -223,20 +254,29 @@ func EstimateBits(numWords int, dict Dictionary) float64 {
 	*/
 }
 
+// The strength and length of Apple style passwords, three or four groups
+// of six.
+const (
+	appleBits       = 87
+	appleLength     = 20
+	appleLongBits   = 116
+	appleLongLength = 27
+)
+
 func MakeApple(config Config, long bool) ([]string, []Stats) {
 	phrases := []string{}
 	stats := []Stats{}
 	for i := 0; i < config.NumPhrases; i++ {
 		phrases = append(phrases, makeApple(long))
 		stat := Stats{
-			NumBits:  87,
-			Length:   20,
-			NumChars: 20,
+			NumBits:  appleBits,
+			Length:   appleLength,
+			NumChars: appleLength,
 		}
 		if long {
-			stat.NumBits = 116
-			stat.Length = 27
-			stat.NumChars = 27
+			stat.NumBits = appleLongBits
+			stat.Length = appleLongLength
+			stat.NumChars = appleLongLength
 		}
 		stats = append(stats, stat)
 	}
@@ -244,50 +284,51 @@ func MakeApple(config Config, long bool) ([]string, []Stats) {
 }
 
 func makeApple(long bool) string {
-	// ascii 'a' is 97, 'z' is 122
-	// 'A' is 65, '0' is 48
+	res, err := appleFrom(newSource(rand.Reader, 64), long)
+	if err != nil {
+		logger().Error("making phrase", "err", err)
+		return ""
+	}
+	return res
+}
+
+// appleFrom makes an Apple style password from s: groups of six lowercase
+// letters, one of them capitalized and another replaced by a digit. Every
+// position is equally likely to be the capital or the digit.
+func appleFrom(s *source, long bool) (string, error) {
 	numChars := 18
 	if long {
 		numChars = 24
 	}
 	alpha := make([]byte, numChars)
 	// numChars number of random chars
-	for i := 0; i < numChars; i++ {
-		n, err := rand.Int(rand.Reader, big.NewInt(26))
+	for i := range alpha {
+		n, err := s.intn(26)
 		if err != nil {
-			return ""
+			return "", err
 		}
-		b := 97 + n.Int64()
-		alpha[i] = byte(b)
+		alpha[i] = byte('a' + n)
 	}
 	// 1 position to capitalize
-	cPosBigInt, err := rand.Int(rand.Reader, big.NewInt(int64(numChars-1)))
+	c, err := s.intn(numChars)
 	if err != nil {
-		return ""
+		return "", err
 	}
-	cPos := cPosBigInt.Int64()
-	c := int(cPos)
-	alpha[c] = alpha[c] - 32
+	alpha[c] -= 'a' - 'A'
 
-	// 1 random digit
-	dBigInt, err := rand.Int(rand.Reader, big.NewInt(10))
+	// 1 random digit, at any position but the capital
+	d, err := s.intn(10)
 	if err != nil {
-		return ""
+		return "", err
 	}
-	d64 := dBigInt.Int64()
-	var dPosBigInt *big.Int
-	for {
-		// find a different random position than capitalized
-		dPosBigInt, err = rand.Int(rand.Reader, big.NewInt(int64(numChars-1)))
-		if err != nil {
-			return ""
-		}
-		if dPosBigInt.Int64() != cPosBigInt.Int64() {
-			break
-		}
+	dPos, err := s.intn(numChars - 1)
+	if err != nil {
+		return "", err
 	}
-	dPos := int(dPosBigInt.Int64())
-	alpha[dPos] = byte(int(d64) + 48) // convert into ascii number char
+	if dPos >= c {
+		dPos++
+	}
+	alpha[dPos] = byte('0' + d)
 
 	res := ""
 	res += string(alpha[0:6])
@@ -299,5 +340,5 @@ func makeApple(long bool) string {
 		res += "-"
 		res += string(alpha[18:24])
 	}
-	return res
+	return res, nil
 }
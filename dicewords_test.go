@@ -111,3 +111,108 @@ func TestMakeApple(t *testing.T) {
 		t.Errorf("invalid stats %v", stats[0])
 	}
 }
+
+func TestRollAndLookup(t *testing.T) {
+	for _, dict := range []Dictionary{Large, Short, Short2} {
+		rolls, err := Roll(dict)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		word, err := Lookup(rolls, dict)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		found, ok := FindWord(word, dict)
+		if !ok || found != rolls {
+			t.Errorf("expected %d for %s, got %d", rolls, word, found)
+		}
+	}
+
+	rolls, ok := FindWord("zucchini", Short2)
+	if !ok || rolls != 6666 {
+		t.Errorf("unexpected %v %v", rolls, ok)
+	}
+	if _, ok := FindWord("zucchini", Short); ok {
+		t.Errorf("zucchini is not in the short list")
+	}
+}
+
+func TestMakePhrase(t *testing.T) {
+	p, err := MakePhrase(4, Short2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(p.Words) != 4 || len(p.Rolls) != 4 {
+		t.Errorf("unexpected phrase %+v", p)
+	}
+	if p.Phrase != strings.Join(p.Words, " ") {
+		t.Errorf("unexpected phrase %q", p.Phrase)
+	}
+	for i, rolls := range p.Rolls {
+		word, _ := Lookup(rolls, Short2)
+		if word != p.Words[i] {
+			t.Errorf("rolls %d: expected %s, got %s", rolls, word, p.Words[i])
+		}
+	}
+	if p.Stats.NumBits != 41.4 || p.Stats.Length != len(p.Phrase) {
+		t.Errorf("unexpected stats %+v", p.Stats)
+	}
+}
+
+func TestMakePhraseNoWords(t *testing.T) {
+	for _, n := range []int{0, -3} {
+		if _, err := MakePhrase(n, Large); err == nil {
+			t.Errorf("%d words: expected an error", n)
+		}
+	}
+}
+
+func TestParseDictionary(t *testing.T) {
+	for _, dict := range []Dictionary{Large, Short, Short2} {
+		got, err := ParseDictionary(dict.String())
+		if err != nil || got != dict {
+			t.Errorf("%v: unexpected %v %v", dict, got, err)
+		}
+	}
+	if _, err := ParseDictionary("huge"); err == nil {
+		t.Errorf("expected error, got none")
+	}
+
+	conf := Config{NumBits: 64, Dict: Short}
+	if conf.Words() != 7 {
+		t.Errorf("unexpected %v", conf.Words())
+	}
+}
+
+func TestConfigWords(t *testing.T) {
+	for _, c := range []struct {
+		conf Config
+		want int
+	}{
+		{Config{NumWords: 3, NumBits: 200}, 3},
+		{Config{}, 5},
+		{Config{NumBits: 64, Dict: Short}, 7},
+		{Config{NumBits: 250}, 20},
+		{Config{NumBits: 260}, 0},
+		{Config{NumBits: 210, Dict: Short}, 0},
+	} {
+		if got := c.conf.Words(); got != c.want {
+			t.Errorf("%+v: expected %d words, got %d", c.conf, c.want, got)
+		}
+	}
+
+	err := Stream(Config{NumBits: 260, NumPhrases: 1}, func(int, Phrase) error { return nil })
+	if err == nil {
+		t.Errorf("expected an error for 260 bits")
+	}
+}
+
+func TestEstimateBits(t *testing.T) {
+	if bits := EstimateBits(5, Large); bits != 64.6 {
+		t.Errorf("unexpected %v", bits)
+	}
+	// 7776^80 is more than a float64 holds
+	if bits := EstimateBits(80, Large); bits != 1034 {
+		t.Errorf("unexpected %v", bits)
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/timothyham/dicewords
 
-go 1.12
+go 1.23
@@ -0,0 +1,77 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import "strings"
+
+// qwertyRows is the US keyboard with each key's unshifted and shifted
+// character. Every row after the first starts half a key to the right of
+// the one above, so key x of a row touches keys x and x+1 of the row above.
+var qwertyRows = []string{
+	"`~ 1! 2@ 3# 4$ 5% 6^ 7& 8* 9( 0) -_ =+",
+	"qQ wW eE rR tT yY uU iI oO pP [{ ]} \\|",
+	"aA sS dD fF gG hH jJ kK lL ;: '\"",
+	"zZ xX cC vV bB nN mM ,< .> /?",
+}
+
+// keyPos is where a character is on the keyboard.
+type keyPos struct {
+	x, y    int
+	shifted bool
+}
+
+var (
+	qwertyKeys   map[rune]keyPos
+	qwertyGrid   map[[2]int]bool
+	qwertyDegree float64
+	qwertyStarts int
+)
+
+// keyDirections are the six neighbours of a key on a slanted keyboard.
+var keyDirections = [6][2]int{{-1, 0}, {0, -1}, {1, -1}, {1, 0}, {0, 1}, {-1, 1}}
+
+func init() {
+	qwertyKeys = make(map[rune]keyPos)
+	qwertyGrid = make(map[[2]int]bool)
+	for y, row := range qwertyRows {
+		x := 0
+		if y > 0 {
+			x = 1
+		}
+		for _, key := range strings.Fields(row) {
+			r := []rune(key)
+			qwertyKeys[r[0]] = keyPos{x, y, false}
+			qwertyKeys[r[1]] = keyPos{x, y, true}
+			qwertyGrid[[2]int{x, y}] = true
+			x++
+		}
+	}
+	neighbours := 0
+	for pos := range qwertyGrid {
+		for _, d := range keyDirections {
+			if qwertyGrid[[2]int{pos[0] + d[0], pos[1] + d[1]}] {
+				neighbours++
+			}
+		}
+	}
+	qwertyStarts = len(qwertyGrid)
+	qwertyDegree = float64(neighbours) / float64(len(qwertyGrid))
+}
+
+// keyDirection returns which neighbour of a b is on the keyboard, or -1 if
+// they don't touch.
+func keyDirection(a, b rune) int {
+	pa, ok := qwertyKeys[a]
+	if !ok {
+		return -1
+	}
+	pb, ok := qwertyKeys[b]
+	if !ok {
+		return -1
+	}
+	for i, d := range keyDirections {
+		if pa.x+d[0] == pb.x && pa.y+d[1] == pb.y {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,280 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Entry is one row of a word list: the dice rolls and the word they pick.
+type Entry struct {
+	Rolls int
+	Word  string
+}
+
+// WordList is a parsed and validated diceware list. It can't be changed
+// once made.
+type WordList struct {
+	name    string
+	dice    int
+	entries []Entry
+	words   []string
+	index   map[string]int
+
+	// prefixLen is the number of letters that tell every word apart, if
+	// every word has that many, and prefixes indexes words by them.
+	prefixLen int
+	prefixes  map[string]int
+}
+
+var largeList, shortList, short2List *WordList
+
+// List returns the built-in list for dict.
+func List(dict Dictionary) *WordList {
+	switch dict {
+	case Short:
+		return shortList
+	case Short2:
+		return short2List
+	}
+	return largeList
+}
+
+// ParseWordList parses rows of "rolls<TAB>word" for dice dice. It checks
+// that there is a row for every roll of the dice, in order, so that the
+// rolls written in row i are the ones that pick it, and that no word is
+// empty or repeated.
+func ParseWordList(name string, rows []string, dice int) (*WordList, error) {
+	size := 1
+	for i := 0; i < dice; i++ {
+		size *= 6
+	}
+	if len(rows) != size {
+		return nil, fmt.Errorf("%s: %d rows for %d dice, expected %d", name, len(rows), dice, size)
+	}
+
+	l := &WordList{
+		name:    name,
+		dice:    dice,
+		entries: make([]Entry, len(rows)),
+		words:   make([]string, len(rows)),
+		index:   make(map[string]int, len(rows)),
+	}
+	for i, row := range rows {
+		rollsStr, word, ok := strings.Cut(row, "\t")
+		if !ok || strings.Contains(word, "\t") {
+			return nil, fmt.Errorf("%s line %d: expected rolls, a tab and a word, got %q", name, i+1, row)
+		}
+		rolls, err := strconv.Atoi(rollsStr)
+		if err != nil || len(rollsStr) != dice {
+			return nil, fmt.Errorf("%s line %d: bad rolls %q", name, i+1, rollsStr)
+		}
+		if want := indexRolls(i, dice); rolls != want {
+			return nil, fmt.Errorf("%s line %d: rolls %d out of order, expected %d", name, i+1, rolls, want)
+		}
+		if word == "" || strings.TrimSpace(word) != word {
+			return nil, fmt.Errorf("%s line %d: bad word %q", name, i+1, word)
+		}
+		if j, ok := l.index[word]; ok {
+			return nil, fmt.Errorf("%s line %d: %q repeats line %d", name, i+1, word, j+1)
+		}
+		l.entries[i] = Entry{Rolls: rolls, Word: word}
+		l.words[i] = word
+		l.index[word] = i
+	}
+
+	n := uniquePrefix(l.words)
+	for _, w := range l.words {
+		if len(w) < n {
+			n = 0
+			break
+		}
+	}
+	if n > 0 {
+		l.prefixLen = n
+		l.prefixes = make(map[string]int, len(l.words))
+		for i, w := range l.words {
+			l.prefixes[w[:n]] = i
+		}
+	}
+	return l, nil
+}
+
+// ReadWordList reads a list of "rolls<TAB>word" lines from r, in the
+// format of the EFF lists, and checks it with ParseWordList. The number of
+// dice is taken from the rolls on the first line.
+func ReadWordList(name string, r io.Reader) (*WordList, error) {
+	rows, err := readRows(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%s: empty list", name)
+	}
+	rollsStr, _, _ := strings.Cut(rows[0], "\t")
+	return ParseWordList(name, rows, len(rollsStr))
+}
+
+// LoadWordList reads the list in the named file with ReadWordList. The
+// list is named after the file.
+func LoadWordList(path string) (*WordList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return ReadWordList(name, f)
+}
+
+// readRows returns the lines of r without line endings or blank lines at
+// the end.
+func readRows(r io.Reader) ([]string, error) {
+	var rows []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		rows = append(rows, strings.TrimSuffix(sc.Text(), "\r"))
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	for len(rows) > 0 && strings.TrimSpace(rows[len(rows)-1]) == "" {
+		rows = rows[:len(rows)-1]
+	}
+	return rows, nil
+}
+
+// builtinList parses one of the embedded lists. One that doesn't parse was
+// changed, so its error joins listsErr and the list is left empty, rather
+// than panicking before dicewords selftest can say what is wrong.
+func builtinList(name string, rows []string, dice int) *WordList {
+	l, err := ParseWordList(name, rows, dice)
+	if err != nil {
+		listsErr = errors.Join(listsErr, err)
+		return &WordList{name: name, dice: dice}
+	}
+	return l
+}
+
+func (l *WordList) Name() string {
+	return l.name
+}
+
+// Dice is the number of dice rolled per word.
+func (l *WordList) Dice() int {
+	return l.dice
+}
+
+func (l *WordList) Len() int {
+	return len(l.entries)
+}
+
+// Entry returns row i, counting from 0.
+func (l *WordList) Entry(i int) Entry {
+	return l.entries[i]
+}
+
+// Words returns a copy of the words in order.
+func (l *WordList) Words() []string {
+	return append([]string(nil), l.words...)
+}
+
+// Lookup returns the word for rolls, such as 11111.
+func (l *WordList) Lookup(rolls int) (string, error) {
+	idx, err := rollsIndex(rolls, l.dice)
+	if err != nil {
+		return "", err
+	}
+	if idx >= len(l.entries) {
+		// only a built-in list that failed to parse is short
+		return "", fmt.Errorf("%s: no row for rolls %d", l.name, rolls)
+	}
+	e := l.entries[idx]
+	if e.Rolls != rolls {
+		// can't happen for a list that passed ParseWordList
+		return "", fmt.Errorf("%s: rolls %d led to row %d", l.name, rolls, e.Rolls)
+	}
+	if Debug {
+		logger().Debug("lookup", "rolls", rolls, "index", idx, "word", e.Word)
+	}
+	return e.Word, nil
+}
+
+// UniquePrefix is the number of leading letters that identify every word
+// of the list, 3 for the EFF short list 2. It is 0 if some word is shorter
+// than that, as in the other EFF lists.
+func (l *WordList) UniquePrefix() int {
+	return l.prefixLen
+}
+
+// FindPrefix returns the entry for the word starting with prefix, which
+// must be UniquePrefix letters long.
+func (l *WordList) FindPrefix(prefix string) (Entry, bool) {
+	i, ok := l.prefixes[prefix]
+	if !ok || len(prefix) != l.prefixLen {
+		return Entry{}, false
+	}
+	return l.entries[i], true
+}
+
+// UniquelyDecodable reports whether phrases from the list can be typed
+// without separators and still read only one way.
+func (l *WordList) UniquelyDecodable() bool {
+	return UniquelyDecodable(l.words)
+}
+
+// Find returns the entry for word.
+func (l *WordList) Find(word string) (Entry, bool) {
+	i, ok := l.index[word]
+	if !ok {
+		return Entry{}, false
+	}
+	return l.entries[i], true
+}
+
+// rollsIndex converts rolls into a row index. Every digit must be 1 to 6
+// and there must be one per die.
+func rollsIndex(rolls, dice int) (int, error) {
+	smallest := 0
+	for i := 0; i < dice; i++ {
+		smallest = smallest*10 + 1
+	}
+	if rolls < smallest {
+		return 0, errors.New(fmt.Sprintf("Roll smaller than %d. Got %d\n", smallest, rolls))
+	}
+
+	idx := 0
+	factor := 1
+	rollsCopy := rolls
+	for i := 0; i < dice; i++ {
+		digit := rolls % 10
+		if digit < 1 || digit > 6 {
+			return 0, errors.New(fmt.Sprintf("Bad roll input %d\n", rollsCopy))
+		}
+		idx += factor * (digit - 1)
+		factor = factor * 6
+		rolls = rolls / 10
+	}
+	if rolls != 0 {
+		return 0, errors.New(fmt.Sprintf("roll outside range %d\n", rollsCopy))
+	}
+	return idx, nil
+}
+
+// indexRolls writes a row index as the dice rolls that select it, the
+// inverse of rollsIndex: index 0 is 11111 for five dice.
+func indexRolls(idx, dice int) int {
+	rolls, factor := 0, 1
+	for i := 0; i < dice; i++ {
+		rolls += factor * (idx%6 + 1)
+		idx /= 6
+		factor *= 10
+	}
+	return rolls
+}
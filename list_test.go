@@ -0,0 +1,152 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestBuiltinLists(t *testing.T) {
+	for _, test := range []struct {
+		dict        Dictionary
+		dice, size  int
+		first, last string
+	}{
+		{Large, 5, 7776, "abacus", "zoom"},
+		{Short, 4, 1296, "acid", "zoom"},
+		{Short2, 4, 1296, "aardvark", "zucchini"},
+	} {
+		l := List(test.dict)
+		if l.Dice() != test.dice || l.Len() != test.size {
+			t.Errorf("%v: unexpected %d dice, %d words", test.dict, l.Dice(), l.Len())
+		}
+		if l.Entry(0).Word != test.first || l.Entry(l.Len()-1).Word != test.last {
+			t.Errorf("%v: unexpected first or last entry", test.dict)
+		}
+		for i := 0; i < l.Len(); i++ {
+			e := l.Entry(i)
+			if indexRolls(i, l.Dice()) != e.Rolls {
+				t.Fatalf("%v: entry %d has rolls %d", test.dict, i, e.Rolls)
+			}
+			if word, err := l.Lookup(e.Rolls); err != nil || word != e.Word {
+				t.Fatalf("%v: lookup %d gave %q %v", test.dict, e.Rolls, word, err)
+			}
+		}
+	}
+
+	words := List(Large).Words()
+	words[0] = "changed"
+	if List(Large).Entry(0).Word != "abacus" {
+		t.Errorf("Words returned the list itself")
+	}
+}
+
+func TestLookupRejectsBadDigits(t *testing.T) {
+	// a 0 digit borrows from the next one and used to land on 16666's word
+	for _, rolls := range []int{21110, 11101, 1111, 111111, 11117} {
+		if word, err := GetLargeWord(rolls); err == nil {
+			t.Errorf("%d: expected error, got %q", rolls, word)
+		}
+	}
+}
+
+func testRows(dice int) []string {
+	n := 1
+	for i := 0; i < dice; i++ {
+		n *= 6
+	}
+	rows := make([]string, n)
+	for i := range rows {
+		rows[i] = fmt.Sprintf("%d\tw%d", indexRolls(i, dice), i)
+	}
+	return rows
+}
+
+func TestParseWordList(t *testing.T) {
+	l, err := ParseWordList("test", testRows(2), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, ok := l.Find("w7"); !ok || e.Rolls != 22 {
+		t.Errorf("unexpected %v %v", e, ok)
+	}
+
+	for name, change := range map[string]func([]string) []string{
+		"short":     func(r []string) []string { return r[:35] },
+		"no tab":    func(r []string) []string { r[3] = "14 w3"; return r },
+		"two tabs":  func(r []string) []string { r[3] = "14\tw3\tx"; return r },
+		"bad rolls": func(r []string) []string { r[3] = "1x\tw3"; return r },
+		"order":     func(r []string) []string { r[3], r[4] = r[4], r[3]; return r },
+		"empty":     func(r []string) []string { r[3] = "14\t"; return r },
+		"repeat":    func(r []string) []string { r[3] = "14\tw2"; return r },
+		"space":     func(r []string) []string { r[3] = "14\tw3 "; return r },
+	} {
+		_, err := ParseWordList("test", change(testRows(2)), 2)
+		if err == nil {
+			t.Errorf("%s: expected error, got none", name)
+		} else if !strings.HasPrefix(err.Error(), "test") {
+			t.Errorf("%s: unexpected error %v", name, err)
+		}
+	}
+}
+
+func TestVerifyLists(t *testing.T) {
+	if err := VerifyLists(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	old := EFFShortWordListRaw
+	defer func() { EFFShortWordListRaw = old }()
+	EFFShortWordListRaw = strings.Replace(old, "\tacid\n", "\tacld\n", 1)
+	err := VerifyLists()
+	if err == nil || !strings.Contains(err.Error(), "eff_short_wordlist_1.txt") {
+		t.Errorf("expected error for the short list, got %v", err)
+	}
+	if r := SelfTest(4096)[0]; r.Err == nil {
+		t.Errorf("%s: expected the changed list to fail", r.Name)
+	}
+
+	defer func(err error) { listsErr = err }(listsErr)
+	listsErr = err
+	if _, err := MakePhrase(5, Large); err != listsErr {
+		t.Errorf("expected phrases refused with %v, got %v", listsErr, err)
+	}
+	if _, err := Roll(Short); err != listsErr {
+		t.Errorf("expected rolls refused with %v, got %v", listsErr, err)
+	}
+}
+
+func TestBuiltinListBroken(t *testing.T) {
+	defer func(err error) { listsErr = err }(listsErr)
+	l := builtinList("broken", testRows(2)[1:], 2)
+	if listsErr == nil || l.Len() != 0 {
+		t.Fatalf("expected an empty list and an error, got %d words and %v", l.Len(), listsErr)
+	}
+	if _, err := l.Lookup(11); err == nil {
+		t.Errorf("expected lookup in an empty list to fail")
+	}
+}
+
+func TestReadWordList(t *testing.T) {
+	data := strings.Join(testRows(3), "\r\n") + "\r\n\n"
+	l, err := ReadWordList("test", strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if l.Dice() != 3 || l.Len() != 216 || l.Entry(215).Word != "w215" {
+		t.Errorf("unexpected list: %d dice, %d words", l.Dice(), l.Len())
+	}
+
+	if _, err := ReadWordList("test", strings.NewReader("")); err == nil {
+		t.Errorf("expected error for an empty list")
+	}
+
+	l, err = LoadWordList("eff_short_wordlist_2_0.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if l.Name() != "eff_short_wordlist_2_0" || l.Len() != 1296 {
+		t.Errorf("unexpected list %s of %d words", l.Name(), l.Len())
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"context"
+	"io"
+	"log/slog"
+)
+
+// Logger receives the package's log output. If nil, slog.Default() is used,
+// wrapped in a RedactingHandler.
+var Logger *slog.Logger
+
+func logger() *slog.Logger {
+	if Logger != nil {
+		return Logger
+	}
+	return slog.New(NewRedactingHandler(slog.Default().Handler()))
+}
+
+// EnableDebug turns on Debug, which traces every roll, list index and word
+// at debug level, and warns loudly that it has.
+func EnableDebug() {
+	Debug = true
+	logger().Warn("DEBUG MODE: rolls, list indexes and words will be logged. " +
+		"Anything generated now is exposed in the log and must not be used.")
+}
+
+// Secret marks a log value that must be redacted. Values logged under the
+// keys in SecretKeys are treated the same way.
+type Secret string
+
+// SecretKeys are attribute keys whose values are always redacted, outside
+// of debug records with Debug on.
+var SecretKeys = map[string]bool{
+	"phrase":   true,
+	"phrases":  true,
+	"word":     true,
+	"words":    true,
+	"roll":     true,
+	"rolls":    true,
+	"index":    true,
+	"password": true,
+	"secret":   true,
+}
+
+const redacted = "[REDACTED]"
+
+// RedactingHandler replaces secret values with [REDACTED] before passing
+// records on. The only exception is debug level records while Debug is on.
+type RedactingHandler struct {
+	next slog.Handler
+}
+
+func NewRedactingHandler(next slog.Handler) *RedactingHandler {
+	return &RedactingHandler{next: next}
+}
+
+// NewLogger returns a logger writing text to w at level and above, with
+// secrets redacted.
+func NewLogger(w io.Writer, level slog.Leveler) *slog.Logger {
+	return slog.New(NewRedactingHandler(slog.NewTextHandler(w, &slog.HandlerOptions{Level: level})))
+}
+
+func (h *RedactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *RedactingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if Debug && r.Level <= slog.LevelDebug {
+		return h.next.Handle(ctx, r)
+	}
+	out := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		out.AddAttrs(redact(a))
+		return true
+	})
+	return h.next.Handle(ctx, out)
+}
+
+// WithAttrs redacts unconditionally, since the attributes may end up on
+// records of any level.
+func (h *RedactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clean := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		clean[i] = redact(a)
+	}
+	return &RedactingHandler{next: h.next.WithAttrs(clean)}
+}
+
+func (h *RedactingHandler) WithGroup(name string) slog.Handler {
+	return &RedactingHandler{next: h.next.WithGroup(name)}
+}
+
+func redact(a slog.Attr) slog.Attr {
+	if SecretKeys[a.Key] {
+		return slog.String(a.Key, redacted)
+	}
+	if _, ok := a.Value.Any().(Secret); ok {
+		return slog.String(a.Key, redacted)
+	}
+	v := a.Value.Resolve()
+	if v.Kind() == slog.KindGroup {
+		group := v.Group()
+		clean := make([]any, len(group))
+		for i, g := range group {
+			clean[i] = redact(g)
+		}
+		return slog.Group(a.Key, clean...)
+	}
+	return slog.Attr{Key: a.Key, Value: v}
+}
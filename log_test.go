@@ -0,0 +1,79 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func withLogger(t *testing.T, level slog.Level) *bytes.Buffer {
+	var buf bytes.Buffer
+	old := Logger
+	Logger = NewLogger(&buf, level)
+	t.Cleanup(func() {
+		Logger = old
+		Debug = false
+	})
+	return &buf
+}
+
+func TestRedaction(t *testing.T) {
+	buf := withLogger(t, slog.LevelDebug)
+
+	phrase, _ := GetPhrase(5, Large)
+	Logger.Info("made", "phrase", phrase, "n", 5)
+	Logger.Debug("made", "words", strings.Split(phrase, " "))
+	Logger.Warn("made", slog.Group("req", "rolls", 12345), "other", Secret(phrase))
+	Logger.With("password", phrase).Error("oops")
+
+	out := buf.String()
+	for _, word := range strings.Split(phrase, " ") {
+		if strings.Contains(out, word) {
+			t.Errorf("log contains %q:\n%s", word, out)
+		}
+	}
+	if strings.Contains(out, "12345") || strings.Contains(out, "lookup") {
+		t.Errorf("log contains rolls:\n%s", out)
+	}
+	if strings.Count(out, redacted) != 5 {
+		t.Errorf("expected 5 redactions:\n%s", out)
+	}
+	if !strings.Contains(out, "n=5") {
+		t.Errorf("log lost other attributes:\n%s", out)
+	}
+}
+
+func TestDebugTrace(t *testing.T) {
+	buf := withLogger(t, slog.LevelDebug)
+
+	EnableDebug()
+	if !strings.Contains(buf.String(), "level=WARN msg=\"DEBUG MODE") {
+		t.Errorf("expected a warning, got:\n%s", buf.String())
+	}
+	buf.Reset()
+
+	phrase, _ := GetPhrase(3, Short)
+	out := buf.String()
+	if strings.Count(out, "msg=lookup") != 3 {
+		t.Errorf("expected 3 lookups:\n%s", out)
+	}
+	for _, word := range strings.Split(phrase, " ") {
+		if !strings.Contains(out, "word="+word) {
+			t.Errorf("trace is missing %q:\n%s", word, out)
+		}
+	}
+
+	// debug traces are still not written at info level
+	buf = withLogger(t, slog.LevelInfo)
+	Debug = true
+	GetPhrase(3, Short)
+	if buf.Len() != 0 {
+		t.Errorf("unexpected output at info level:\n%s", buf.String())
+	}
+	Logger.Info("made", "phrase", "secret words")
+	if strings.Contains(buf.String(), "secret words") {
+		t.Errorf("info record not redacted in debug mode:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ListOptions says which words MakeList may use.
+type ListOptions struct {
+	// Dice is 4 for a list of 1296 words or 5 for 7776. Zero means 5.
+	Dice int
+	// MinLength and MaxLength bound the letters in a word. Zero means 3
+	// and 9, like the EFF large list.
+	MinLength, MaxLength int
+	// Chars are the letters words may use. Empty means a to z.
+	Chars string
+	// Blocklist words are never used.
+	Blocklist []string
+	// UniquePrefix, if set, keeps only words whose first UniquePrefix
+	// letters are unlike any other word's, as in the EFF short list 2.
+	UniquePrefix int
+	// PrefixFree drops words that start other words, so phrases typed
+	// without spaces can be read only one way.
+	PrefixFree bool
+}
+
+// CountWords counts the words of a text corpus, in lower case. A word is a
+// run of letters.
+func CountWords(r io.Reader) (map[string]int, error) {
+	counts := make(map[string]int)
+	sc := bufio.NewScanner(r)
+	sc.Split(bufio.ScanWords)
+	for sc.Scan() {
+		for _, w := range strings.FieldsFunc(sc.Text(), func(r rune) bool { return !unicode.IsLetter(r) }) {
+			counts[strings.ToLower(w)]++
+		}
+	}
+	return counts, sc.Err()
+}
+
+// ReadFrequencies reads lines of a word and its count, such as "the 23135851162".
+// A line with only a word counts once, so a plain list of words works too.
+func ReadFrequencies(r io.Reader) (map[string]int, error) {
+	counts := make(map[string]int)
+	sc := bufio.NewScanner(r)
+	line := 0
+	for sc.Scan() {
+		line++
+		fields := strings.Fields(sc.Text())
+		switch len(fields) {
+		case 0:
+			continue
+		case 1:
+			counts[strings.ToLower(fields[0])]++
+		case 2:
+			n, err := strconv.Atoi(fields[1])
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("line %d: bad count %q", line, fields[1])
+			}
+			counts[strings.ToLower(fields[0])] += n
+		default:
+			return nil, fmt.Errorf("line %d: expected a word and a count, got %q", line, sc.Text())
+		}
+	}
+	return counts, sc.Err()
+}
+
+// MakeList makes a diceware list from the most frequent words in counts
+// that opts allows. The words are sorted and numbered with their rolls like
+// the EFF lists. It fails if too few words qualify.
+func MakeList(name string, counts map[string]int, opts ListOptions) (*WordList, error) {
+	if opts.Dice == 0 {
+		opts.Dice = 5
+	}
+	if opts.MinLength == 0 {
+		opts.MinLength = 3
+	}
+	if opts.MaxLength == 0 {
+		opts.MaxLength = 9
+	}
+	if opts.Chars == "" {
+		opts.Chars = "abcdefghijklmnopqrstuvwxyz"
+	}
+	if opts.Dice < 1 || opts.Dice > 6 {
+		return nil, fmt.Errorf("%s: %d dice, expected 1 to 6", name, opts.Dice)
+	}
+	size := 1
+	for i := 0; i < opts.Dice; i++ {
+		size *= 6
+	}
+
+	blocked := make(map[string]bool, len(opts.Blocklist))
+	for _, w := range opts.Blocklist {
+		blocked[strings.ToLower(w)] = true
+	}
+	var candidates []string
+	for w := range counts {
+		n := utf8.RuneCountInString(w)
+		if n < opts.MinLength || n > opts.MaxLength || blocked[w] || !onlyChars(w, opts.Chars) {
+			continue
+		}
+		if opts.UniquePrefix > 0 && n < opts.UniquePrefix {
+			continue
+		}
+		candidates = append(candidates, w)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if counts[a] != counts[b] {
+			return counts[a] > counts[b]
+		}
+		return a < b
+	})
+
+	chosen := make([]string, 0, size)
+	prefixes := make(map[string]bool)
+	starts := make(map[string]bool)
+	words := make(map[string]bool)
+	for _, w := range candidates {
+		if len(chosen) == size {
+			break
+		}
+		var p string
+		if opts.UniquePrefix > 0 {
+			p = runePrefix(w, opts.UniquePrefix)
+			if prefixes[p] {
+				continue
+			}
+		}
+		if opts.PrefixFree && (starts[w] || hasWordPrefix(w, words)) {
+			continue
+		}
+		chosen = append(chosen, w)
+		words[w] = true
+		if opts.UniquePrefix > 0 {
+			prefixes[p] = true
+		}
+		if opts.PrefixFree {
+			for i := range w {
+				if i > 0 {
+					starts[w[:i]] = true
+				}
+			}
+		}
+	}
+	if len(chosen) < size {
+		return nil, fmt.Errorf("%s: only %d words qualify, %d dice need %d", name, len(chosen), opts.Dice, size)
+	}
+
+	sort.Strings(chosen)
+	rows := make([]string, size)
+	for i, w := range chosen {
+		rows[i] = fmt.Sprintf("%d\t%s", indexRolls(i, opts.Dice), w)
+	}
+	return ParseWordList(name, rows, opts.Dice)
+}
+
+func onlyChars(w, chars string) bool {
+	for _, r := range w {
+		if !strings.ContainsRune(chars, r) {
+			return false
+		}
+	}
+	return true
+}
+
+func runePrefix(w string, n int) string {
+	for i := range w {
+		if n == 0 {
+			return w[:i]
+		}
+		n--
+	}
+	return w
+}
+
+// hasWordPrefix reports whether a word in words starts w.
+func hasWordPrefix(w string, words map[string]bool) bool {
+	for i := range w {
+		if i > 0 && words[w[:i]] {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteTo writes the list as "rolls<TAB>word" lines, the format of the EFF
+// lists and of ReadWordList.
+func (l *WordList) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	var n int64
+	for _, e := range l.entries {
+		m, err := fmt.Fprintf(bw, "%d\t%s\n", e.Rolls, e.Word)
+		n += int64(m)
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, bw.Flush()
+}
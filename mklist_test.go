@@ -0,0 +1,88 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCountWords(t *testing.T) {
+	counts, err := CountWords(strings.NewReader("The cat, the hat.\nIt's the CAT!"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]int{"the": 3, "cat": 2, "hat": 1, "it": 1, "s": 1}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("unexpected counts %v", counts)
+	}
+
+	counts, err = ReadFrequencies(strings.NewReader("the 10\nCat 4\n\nhat\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = map[string]int{"the": 10, "cat": 4, "hat": 1}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("unexpected counts %v", counts)
+	}
+	if _, err := ReadFrequencies(strings.NewReader("the ten\n")); err == nil {
+		t.Errorf("expected error for a bad count")
+	}
+}
+
+func TestMakeList(t *testing.T) {
+	// every word from aaa to zzz, with counts falling from aaa, plus
+	// longer words that start some of them
+	counts := make(map[string]int)
+	n := 0
+	for _, a := range "abcdefghijklmnopqrstuvwxyz" {
+		for _, b := range "abcdefghijklmnopqrstuvwxyz" {
+			for _, c := range "abcdefghijklmnopqrstuvwxyz" {
+				counts[string([]rune{a, b, c})] = 100000 - n
+				n++
+			}
+		}
+	}
+	counts["aaab"] = 1000000
+	counts["toolongforit"] = 1000000
+	counts["ok!"] = 1000000
+
+	l, err := MakeList("test", counts, ListOptions{Dice: 2, Blocklist: []string{"AAC"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	words := l.Words()
+	if l.Len() != 36 || words[0] != "aaa" || words[1] != "aaab" || words[2] != "aab" || words[3] != "aad" {
+		t.Errorf("unexpected words %v", words)
+	}
+	if e := l.Entry(35); e.Rolls != 66 {
+		t.Errorf("unexpected last entry %v", e)
+	}
+
+	l, err = MakeList("test", counts, ListOptions{Dice: 2, UniquePrefix: 2, PrefixFree: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	a := AnalyzeWords(l.Words())
+	if a.UniquePrefix > 2 || len(a.PrefixPairs) != 0 {
+		t.Errorf("unexpected unique prefix %d, prefix pairs %v", a.UniquePrefix, a.PrefixPairs)
+	}
+
+	var buf bytes.Buffer
+	if _, err := l.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	back, err := ReadWordList("test", &buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(back.Words(), l.Words()) {
+		t.Errorf("list changed on the way back")
+	}
+
+	_, err = MakeList("test", counts, ListOptions{Dice: 2, UniquePrefix: 1})
+	if err == nil || !strings.Contains(err.Error(), "only 26 words") {
+		t.Errorf("expected too few words, got %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// PwnedList looks passwords up in a local copy of the Pwned Passwords list
+// of breached passwords: lines of an upper case SHA-1 hash, a colon and a
+// count, such as "7C4A8D09CA3762AF61E59520943DC26494F8941B:24230577",
+// sorted by hash as downloaded. It binary searches the file, reading a few
+// blocks per lookup, and never loads it into memory.
+type PwnedList struct {
+	r    io.ReaderAt
+	size int64
+	c    io.Closer
+}
+
+// pwnedBlock is how much is read at a time when looking for a line.
+const pwnedBlock = 512
+
+// NewPwnedList searches the size bytes of r.
+func NewPwnedList(r io.ReaderAt, size int64) *PwnedList {
+	return &PwnedList{r: r, size: size}
+}
+
+// OpenPwnedList opens the named file for NewPwnedList.
+func OpenPwnedList(path string) (*PwnedList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	p := NewPwnedList(f, fi.Size())
+	p.c = f
+	return p, nil
+}
+
+// Close closes the file opened by OpenPwnedList.
+func (p *PwnedList) Close() error {
+	if p.c == nil {
+		return nil
+	}
+	return p.c.Close()
+}
+
+// Count returns how many times password appears in breaches, 0 if never.
+func (p *PwnedList) Count(password string) (int, error) {
+	sum := sha1.Sum([]byte(password))
+	return p.CountHash(hex.EncodeToString(sum[:]))
+}
+
+// CountHash is Count for the hex SHA-1 of a password.
+func (p *PwnedList) CountHash(hash string) (int, error) {
+	hash = strings.ToUpper(hash)
+	if len(hash) != 2*sha1.Size {
+		return 0, fmt.Errorf("bad SHA-1 hash %q", hash)
+	}
+	target := []byte(hash)
+
+	// the line for hash, if any, starts in [lo, hi)
+	lo, hi := int64(0), p.size
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		start, line, err := p.lineAt(mid)
+		if err != nil {
+			return 0, err
+		}
+		if start >= hi {
+			hi = mid
+			continue
+		}
+		lineHash, count, ok := bytes.Cut(line, []byte(":"))
+		if !ok || len(lineHash) != len(target) {
+			return 0, fmt.Errorf("pwned passwords line at byte %d: expected hash:count, got %q", start, line)
+		}
+		switch bytes.Compare(bytes.ToUpper(lineHash), target) {
+		case 0:
+			n, err := strconv.Atoi(string(bytes.TrimSpace(count)))
+			if err != nil {
+				return 0, fmt.Errorf("pwned passwords line at byte %d: bad count %q", start, count)
+			}
+			return n, nil
+		case -1:
+			lo = start + int64(len(line)) + 1
+		default:
+			hi = mid
+		}
+	}
+	return 0, nil
+}
+
+// lineAt returns the first line starting at or after off, without its line
+// ending. At the end of the file start is the size.
+func (p *PwnedList) lineAt(off int64) (int64, []byte, error) {
+	start := off
+	if off > 0 {
+		// the line starts after the first newline from off-1
+		pos := off - 1
+		for {
+			buf, err := p.read(pos, pwnedBlock)
+			if err != nil {
+				return 0, nil, err
+			}
+			if i := bytes.IndexByte(buf, '\n'); i >= 0 {
+				start = pos + int64(i) + 1
+				break
+			}
+			if pos+int64(len(buf)) >= p.size {
+				return p.size, nil, nil
+			}
+			pos += int64(len(buf))
+		}
+	}
+
+	var line []byte
+	for pos := start; pos < p.size; {
+		buf, err := p.read(pos, pwnedBlock)
+		if err != nil {
+			return 0, nil, err
+		}
+		if i := bytes.IndexByte(buf, '\n'); i >= 0 {
+			line = append(line, buf[:i]...)
+			break
+		}
+		line = append(line, buf...)
+		pos += int64(len(buf))
+	}
+	return start, bytes.TrimSuffix(line, []byte("\r")), nil
+}
+
+// read reads up to n bytes at off, fewer at the end of the file.
+func (p *PwnedList) read(off int64, n int) ([]byte, error) {
+	if off >= p.size {
+		return nil, nil
+	}
+	buf := make([]byte, min(int64(n), p.size-off))
+	if _, err := p.r.ReadAt(buf, off); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return buf, nil
+}
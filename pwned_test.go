@@ -0,0 +1,120 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// pwnedFile makes a Pwned Passwords file of passwords, each seen i+1 times.
+func pwnedFile(passwords []string, eol string) []byte {
+	var lines []string
+	for i, p := range passwords {
+		sum := sha1.Sum([]byte(p))
+		lines = append(lines, fmt.Sprintf("%s:%d", strings.ToUpper(hex.EncodeToString(sum[:])), i+1))
+	}
+	sort.Strings(lines)
+	return []byte(strings.Join(lines, eol) + eol)
+}
+
+func TestPwnedList(t *testing.T) {
+	words := List(Short).Words()
+	for _, eol := range []string{"\n", "\r\n"} {
+		data := pwnedFile(words[:1000], eol)
+		pl := NewPwnedList(bytes.NewReader(data), int64(len(data)))
+		for i, w := range words[:1000] {
+			if n, err := pl.Count(w); err != nil || n != i+1 {
+				t.Fatalf("%q: expected %d, got %d %v", w, i+1, n, err)
+			}
+		}
+		for _, w := range words[1000:] {
+			if n, err := pl.Count(w); err != nil || n != 0 {
+				t.Fatalf("%q: expected 0, got %d %v", w, n, err)
+			}
+		}
+	}
+
+	empty := NewPwnedList(bytes.NewReader(nil), 0)
+	if n, err := empty.Count("password"); err != nil || n != 0 {
+		t.Errorf("expected 0, got %d %v", n, err)
+	}
+
+	bad := []byte("not a hash\n")
+	if _, err := NewPwnedList(bytes.NewReader(bad), int64(len(bad))).Count("password"); err == nil {
+		t.Errorf("expected error for a bad file")
+	}
+}
+
+func TestReject(t *testing.T) {
+	conf := MakeConfig()
+	conf.NumWords = 4
+	conf.NumPhrases = 5
+	conf.Seed = []byte("reject test")
+	var want []string
+	for p := range Phrases(conf) {
+		want = append(want, p.Phrase)
+	}
+
+	// the list has phrase 2, which must be replaced, and nothing else
+	data := pwnedFile([]string{want[2]}, "\n")
+	pl := NewPwnedList(bytes.NewReader(data), int64(len(data)))
+	var rejected []string
+	conf.Reject = func(p Phrase) (bool, error) {
+		n, err := pl.Count(p.Phrase)
+		if n > 0 {
+			rejected = append(rejected, p.Phrase)
+		}
+		return n > 0, err
+	}
+	var got []string
+	err := Stream(conf, func(i int, p Phrase) error {
+		got = append(got, p.Phrase)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rejected) != 1 || rejected[0] != want[2] {
+		t.Errorf("expected %q rejected, got %q", want[2], rejected)
+	}
+	for i := range want {
+		if (got[i] == want[i]) != (i != 2) {
+			t.Errorf("phrase %d: was %q, got %q", i, want[i], got[i])
+		}
+	}
+
+	tries := 0
+	conf.Reject = func(p Phrase) (bool, error) {
+		tries++
+		return true, nil
+	}
+	conf.NumPhrases = 1
+	err = Stream(conf, func(i int, p Phrase) error {
+		t.Errorf("unexpected phrase %q", p.Phrase)
+		return nil
+	})
+	if !errors.Is(err, ErrTooManyRejects) || tries != maxRejects {
+		t.Errorf("expected ErrTooManyRejects after %d tries, got %v after %d", maxRejects, err, tries)
+	}
+
+	conf.Seed = nil
+	conf.Reject = func(p Phrase) (bool, error) {
+		return strings.HasPrefix(p.Phrase, "a"), nil
+	}
+	conf.NumPhrases = 200
+	err = Stream(conf, func(i int, p Phrase) error {
+		if strings.HasPrefix(p.Phrase, "a") {
+			t.Errorf("unexpected phrase %q", p.Phrase)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// EntropySelfTest reads from the random source and fails if it errors or
+// gives obviously broken output: a block of one repeated byte, the same
+// block twice, or a block failing HealthTest.
+func EntropySelfTest() error {
+	a := make([]byte, 64)
+	b := make([]byte, 64)
+	if _, err := io.ReadFull(rand.Reader, a); err != nil {
+		return fmt.Errorf("reading random source: %v", err)
+	}
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return fmt.Errorf("reading random source: %v", err)
+	}
+	if bytes.Count(a, a[:1]) == len(a) {
+		return errors.New("random source returned a repeated byte")
+	}
+	if bytes.Equal(a, b) {
+		return errors.New("random source repeated itself")
+	}
+	return HealthTest(rand.Reader, 4096)
+}
+
+// The health tests follow NIST SP 800-90B section 4.4, treating each byte
+// as a sample. The OS generator claims 8 bits of entropy per byte; the
+// cutoffs assume only healthEntropy bits, and a false alarm rate of
+// healthAlpha per sample, so a working source practically never fails.
+const (
+	healthEntropy = 6.0
+	healthAlpha   = 1.0 / (1 << 30)
+	aptWindow     = 512
+)
+
+// RCTCutoff is the repetition count test cutoff: a run of this many equal
+// bytes fails.
+var RCTCutoff = 1 + int(math.Ceil(-math.Log2(healthAlpha)/healthEntropy))
+
+// APTCutoff is the adaptive proportion test cutoff: the first byte of a
+// window of aptWindow bytes turning up this many times fails.
+var APTCutoff = aptCutoff(aptWindow, math.Pow(2, -healthEntropy), healthAlpha)
+
+// aptCutoff is 1 + CRITBINOM(w, p, 1-alpha) from SP 800-90B: one more than
+// the smallest c with P(X > c) <= alpha, for X binomial over w trials with
+// probability p.
+func aptCutoff(w int, p, alpha float64) int {
+	tail := 1.0
+	for c := 0; c <= w; c++ {
+		tail -= math.Exp(logBinomial(w, c) + float64(c)*math.Log(p) + float64(w-c)*math.Log1p(-p))
+		if tail <= alpha {
+			return c + 1
+		}
+	}
+	return w
+}
+
+func logBinomial(n, k int) float64 {
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+	return a - b - c
+}
+
+// healthChecker runs the repetition count and adaptive proportion tests on
+// samples as they arrive.
+type healthChecker struct {
+	n int
+
+	last   byte
+	run    int
+	maxRun int
+
+	aptFirst byte
+	aptCount int
+	aptSeen  int
+	maxAPT   int
+}
+
+func (h *healthChecker) add(samples []byte) error {
+	for _, b := range samples {
+		if h.n > 0 && b == h.last {
+			h.run++
+		} else {
+			h.last, h.run = b, 1
+		}
+		if h.run > h.maxRun {
+			h.maxRun = h.run
+		}
+		if h.run >= RCTCutoff {
+			return fmt.Errorf("repetition count test: byte %#02x repeated %d times at sample %d", b, h.run, h.n)
+		}
+
+		if h.aptSeen == 0 {
+			h.aptFirst, h.aptCount = b, 1
+		} else if b == h.aptFirst {
+			h.aptCount++
+		}
+		h.aptSeen++
+		if h.aptCount > h.maxAPT {
+			h.maxAPT = h.aptCount
+		}
+		if h.aptCount >= APTCutoff {
+			return fmt.Errorf("adaptive proportion test: byte %#02x seen %d times in a window of %d at sample %d", h.aptFirst, h.aptCount, aptWindow, h.n)
+		}
+		if h.aptSeen == aptWindow {
+			h.aptSeen = 0
+		}
+		h.n++
+	}
+	return nil
+}
+
+// HealthTest reads n bytes from r and runs the repetition count and
+// adaptive proportion tests on them.
+func HealthTest(r io.Reader, n int) error {
+	var h healthChecker
+	buf := make([]byte, 4096)
+	for n > 0 {
+		chunk := buf
+		if n < len(chunk) {
+			chunk = chunk[:n]
+		}
+		if _, err := io.ReadFull(r, chunk); err != nil {
+			return fmt.Errorf("reading random source: %v", err)
+		}
+		if err := h.add(chunk); err != nil {
+			return err
+		}
+		n -= len(chunk)
+	}
+	return nil
+}
+
+// SelfTestResult is the outcome of one check made by SelfTest.
+type SelfTestResult struct {
+	Name string
+	Err  error
+}
+
+// knownAnswers are rolls with the words the EFF lists give for them.
+var knownAnswers = []struct {
+	dict  Dictionary
+	rolls int
+	word  string
+}{
+	{Large, 11111, "abacus"},
+	{Large, 35164, "jokester"},
+	{Large, 66666, "zoom"},
+	{Short, 1111, "acid"},
+	{Short, 3516, "jaws"},
+	{Short, 6666, "zoom"},
+	{Short2, 1111, "aardvark"},
+	{Short2, 3516, "imbecile"},
+	{Short2, 6666, "zucchini"},
+}
+
+// SelfTest checks the word lists against their digests and known answers,
+// checks their sizes and that no word repeats, and runs the health tests
+// on samples bytes of the random source. It returns one result per check.
+func SelfTest(samples int) []SelfTestResult {
+	results := []SelfTestResult{{"word list digests", VerifyLists()}}
+
+	for _, dict := range []Dictionary{Large, Short, Short2} {
+		var err error
+		for _, ka := range knownAnswers {
+			if ka.dict != dict {
+				continue
+			}
+			word, lookupErr := Lookup(ka.rolls, dict)
+			if lookupErr != nil || word != ka.word {
+				err = fmt.Errorf("%d gave %q, expected %q", ka.rolls, word, ka.word)
+				break
+			}
+			if rolls, ok := FindWord(ka.word, dict); !ok || rolls != ka.rolls {
+				err = fmt.Errorf("%q gave %d, expected %d", ka.word, rolls, ka.rolls)
+				break
+			}
+		}
+		results = append(results, SelfTestResult{fmt.Sprintf("%v list known answers", dict), err})
+		results = append(results, SelfTestResult{fmt.Sprintf("%v list size and uniqueness", dict), checkList(List(dict))})
+	}
+
+	results = append(results, SelfTestResult{"random source", EntropySelfTest()})
+	results = append(results, SelfTestResult{
+		fmt.Sprintf("random source health, %d bytes (RCT cutoff %d, APT cutoff %d/%d)", samples, RCTCutoff, APTCutoff, aptWindow),
+		HealthTest(rand.Reader, samples),
+	})
+	return results
+}
+
+func checkList(l *WordList) error {
+	size := 1
+	for i := 0; i < l.Dice(); i++ {
+		size *= 6
+	}
+	if l.Len() != size {
+		return fmt.Errorf("%d words, expected %d", l.Len(), size)
+	}
+	seen := make(map[string]bool, l.Len())
+	for i := 0; i < l.Len(); i++ {
+		word := l.Entry(i).Word
+		if seen[word] {
+			return fmt.Errorf("%q repeats", word)
+		}
+		seen[word] = true
+	}
+	return nil
+}
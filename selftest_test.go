@@ -0,0 +1,65 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"bytes"
+	"crypto/rand"
+	"strings"
+	"testing"
+)
+
+func TestCutoffs(t *testing.T) {
+	// 1 + ceil(30/6)
+	if RCTCutoff != 6 {
+		t.Errorf("unexpected RCT cutoff %d", RCTCutoff)
+	}
+	// SP 800-90B table 2 gives 13 for H=8, W=512 at alpha 2^-20
+	if c := aptCutoff(512, 1.0/256, 1.0/(1<<20)); c != 13 {
+		t.Errorf("unexpected APT cutoff %d", c)
+	}
+	if APTCutoff < 20 || APTCutoff > 40 {
+		t.Errorf("unexpected APT cutoff %d", APTCutoff)
+	}
+}
+
+func TestHealthTest(t *testing.T) {
+	if err := HealthTest(rand.Reader, 1<<16); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	stuck := bytes.Repeat([]byte{0x42}, 100)
+	err := HealthTest(bytes.NewReader(stuck), len(stuck))
+	if err == nil || !strings.Contains(err.Error(), "repetition count") {
+		t.Errorf("expected repetition count failure, got %v", err)
+	}
+
+	// alternating bytes never repeat but half of every window is 0x00
+	biased := bytes.Repeat([]byte{0x00, 0x01}, 512)
+	err = HealthTest(bytes.NewReader(biased), len(biased))
+	if err == nil || !strings.Contains(err.Error(), "adaptive proportion") {
+		t.Errorf("expected adaptive proportion failure, got %v", err)
+	}
+
+	if err := HealthTest(bytes.NewReader(nil), 10); err == nil {
+		t.Errorf("expected read error, got none")
+	}
+}
+
+func TestSelfTest(t *testing.T) {
+	results := SelfTest(1 << 16)
+	if len(results) != 9 {
+		t.Errorf("expected 9 results, got %d", len(results))
+	}
+	for _, r := range results {
+		if r.Err != nil {
+			t.Errorf("%s: %v", r.Name, r.Err)
+		}
+	}
+
+	old := knownAnswers[0].word
+	knownAnswers[0].word = "abacas"
+	defer func() { knownAnswers[0].word = old }()
+	if r := SelfTest(16)[1]; r.Err == nil {
+		t.Errorf("%s: expected failure", r.Name)
+	}
+}
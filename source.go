@@ -0,0 +1,94 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// source hands out uniform random numbers drawn from a buffer of random
+// bytes, refilled from r a block at a time rather than a few bytes per die.
+type source struct {
+	r   io.Reader
+	buf []byte
+	pos int
+}
+
+func newSource(r io.Reader, size int) *source {
+	buf := make([]byte, size)
+	return &source{r: r, buf: buf, pos: size}
+}
+
+func (s *source) uint32() (uint32, error) {
+	if s.pos+4 > len(s.buf) {
+		if _, err := io.ReadFull(s.r, s.buf); err != nil {
+			return 0, err
+		}
+		s.pos = 0
+	}
+	v := binary.LittleEndian.Uint32(s.buf[s.pos:])
+	s.pos += 4
+	return v, nil
+}
+
+// intn returns a uniform number in [0, n). Draws at or above the largest
+// multiple of n below 2^32 are rejected, so there is no modulo bias.
+func (s *source) intn(n int) (int, error) {
+	limit := (1 << 32) / uint64(n) * uint64(n)
+	for {
+		v, err := s.uint32()
+		if err != nil {
+			return 0, err
+		}
+		if uint64(v) < limit {
+			return int(uint64(v) % uint64(n)), nil
+		}
+	}
+}
+
+// phraseFrom makes a phrase of numWords words from s.
+func phraseFrom(s *source, numWords int, dict Dictionary) (Phrase, error) {
+	if numWords < 1 {
+		return Phrase{}, fmt.Errorf("a phrase needs at least 1 word, not %d", numWords)
+	}
+	if listsErr != nil {
+		return Phrase{}, listsErr
+	}
+	l := List(dict)
+	p := Phrase{
+		Dict:  dict,
+		Words: make([]string, numWords),
+		Rolls: make([]int, numWords),
+	}
+
+	var b strings.Builder
+	numChars := 0
+	for i := range p.Words {
+		idx, err := s.intn(len(l.words))
+		if err != nil {
+			return Phrase{}, err
+		}
+		e := l.entries[idx]
+		word := e.Word
+		p.Words[i] = word
+		p.Rolls[i] = e.Rolls
+		if Debug {
+			logger().Debug("lookup", "rolls", p.Rolls[i], "index", idx, "word", word)
+		}
+
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(word)
+		numChars += len(word)
+	}
+	p.Phrase = b.String()
+	p.Stats = Stats{
+		NumBits:  EstimateBits(numWords, dict),
+		Length:   len(p.Phrase),
+		NumChars: numChars,
+	}
+	return p, nil
+}
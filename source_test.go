@@ -0,0 +1,156 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestSourceIntn(t *testing.T) {
+	// 0xffffffff is above the largest multiple of 6 below 2^32 and must be
+	// rejected, leaving the next draw, 7 % 6.
+	in := []byte{0xff, 0xff, 0xff, 0xff, 7, 0, 0, 0}
+	s := newSource(bytes.NewReader(in), 8)
+	n, err := s.intn(6)
+	if err != nil || n != 1 {
+		t.Errorf("expected 1, got %d %v", n, err)
+	}
+	if _, err := s.intn(6); err == nil {
+		t.Errorf("expected error at end of input, got none")
+	}
+
+	s = newSource(rand.Reader, 64)
+	counts := make([]int, 6)
+	for i := 0; i < 6000; i++ {
+		n, _ := s.intn(6)
+		counts[n]++
+	}
+	for face, c := range counts {
+		if c < 800 || c > 1200 {
+			t.Errorf("face %d came up %d times in 6000", face, c)
+		}
+	}
+}
+
+// legacyRows are the word lists as the legacy code held them, one
+// "rolls\tword" row per line of the list file.
+var legacyRows = map[Dictionary][]string{}
+
+func init() {
+	for _, dict := range []Dictionary{Large, Short, Short2} {
+		l := List(dict)
+		for i := 0; i < l.Len(); i++ {
+			e := l.Entry(i)
+			legacyRows[dict] = append(legacyRows[dict], fmt.Sprintf("%d\t%s", e.Rolls, e.Word))
+		}
+	}
+}
+
+// legacyGetWord is getWord as it was before the lists were parsed once: the
+// rolls turned back into a row index, and the row split on every call.
+func legacyGetWord(list []string, rolls, smallest int) (string, error) {
+	if rolls < smallest {
+		return "", fmt.Errorf("Roll smaller than %d. Got %d\n", smallest, rolls)
+	}
+	// convert rolls into row index
+	idx := 0
+	factor := 1
+	rollsCopy := rolls
+	for {
+		digit := rolls % 10
+		if digit > 6 {
+			return "", fmt.Errorf("Bad roll input %d\n", rollsCopy)
+		}
+		idx += factor * (digit - 1)
+		factor = factor * 6
+		rolls = rolls / 10
+
+		if rolls == 0 {
+			break
+		}
+	}
+
+	if idx < 0 || idx > len(list)-1 {
+		return "", fmt.Errorf("roll outside range %d\n", rollsCopy)
+	}
+	row := list[idx]
+	fields := strings.Split(row, "\t")
+	return fields[1], nil
+}
+
+// legacyPhrase is GetPhrase as it was before the buffered source: a big.Int
+// draw per die, rolls decoded again by legacyGetWord with a strings.Split
+// per row, and the phrase built by concatenation. It is kept to benchmark
+// against.
+func legacyPhrase(numWords int, dict Dictionary) string {
+	six := big.NewInt(6)
+	count := 5
+	smallest := 11111
+	if dict != Large {
+		count = 4
+		smallest = 1111
+	}
+	nums := make([]int, count)
+	res := ""
+	for j := 0; j < numWords; j++ {
+		for i := 0; i < count; i++ {
+			n, err := rand.Int(rand.Reader, six)
+			if err != nil {
+				panic(err)
+			}
+			nums[i] = int(n.Int64())
+		}
+		factor := 1
+		rolls := 0
+		for i := count - 1; i > -1; i-- {
+			rolls = rolls + factor*(nums[i]+1)
+			factor = factor * 10
+		}
+		word, err := legacyGetWord(legacyRows[dict], rolls, smallest)
+		if err != nil {
+			panic(err)
+		}
+		res = res + word + " "
+	}
+	return strings.TrimSpace(res)
+}
+
+func TestLegacyGetWord(t *testing.T) {
+	for _, dict := range []Dictionary{Large, Short, Short2} {
+		l := List(dict)
+		for _, i := range []int{0, 1, l.Len() / 2, l.Len() - 1} {
+			e := l.Entry(i)
+			smallest := 11111
+			if dict != Large {
+				smallest = 1111
+			}
+			word, err := legacyGetWord(legacyRows[dict], e.Rolls, smallest)
+			if err != nil || word != e.Word {
+				t.Errorf("%v %d: expected %s, got %s %v", dict, e.Rolls, e.Word, word, err)
+			}
+		}
+	}
+}
+
+func BenchmarkPhrase(b *testing.B) {
+	for _, dict := range []Dictionary{Large, Short} {
+		b.Run(fmt.Sprintf("legacy/%v", dict), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				legacyPhrase(6, dict)
+			}
+		})
+		b.Run(fmt.Sprintf("MakePhrase/%v", dict), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				MakePhrase(6, dict)
+			}
+		})
+		b.Run(fmt.Sprintf("Phrases/%v", dict), func(b *testing.B) {
+			for range Phrases(Config{NumPhrases: b.N, NumWords: 6, Dict: dict}) {
+			}
+		})
+	}
+}
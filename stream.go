@@ -0,0 +1,171 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	mrand "math/rand/v2"
+	"runtime"
+	"strings"
+)
+
+// Phrases generates config.NumPhrases phrases one at a time, so any number
+// of them can be made in constant memory. With config.Workers above one they
+// are made in parallel but still yielded in order. Generation stops at the
+// first error, which is yielded with an empty Phrase.
+func Phrases(config Config) iter.Seq2[Phrase, error] {
+	return func(yield func(Phrase, error) bool) {
+		if config.NoSeparator && !List(config.Dict).UniquelyDecodable() {
+			yield(Phrase{}, ErrNotDecodable)
+			return
+		}
+		if config.Words() == 0 {
+			yield(Phrase{}, fmt.Errorf("%d bits need more than %d words of the %v list", config.NumBits, MaxWords, config.Dict))
+			return
+		}
+		// more workers than phrases or CPUs would only cost memory
+		workers := min(config.Workers, config.NumPhrases, 4*runtime.GOMAXPROCS(0))
+		if workers < 2 {
+			src := newSource(rand.Reader, sourceBlock)
+			for i := 0; i < config.NumPhrases; i++ {
+				p, err := makeNth(config, i, src)
+				if !yield(p, err) || err != nil {
+					return
+				}
+			}
+			return
+		}
+		parallel(config, workers, yield)
+	}
+}
+
+// Stream calls fn with each phrase of Phrases and its index, stopping at the
+// first error from generation or from fn.
+func Stream(config Config, fn func(i int, p Phrase) error) error {
+	i := 0
+	for p, err := range Phrases(config) {
+		if err != nil {
+			return err
+		}
+		if err := fn(i, p); err != nil {
+			return err
+		}
+		i++
+	}
+	return nil
+}
+
+// sourceBlock is how many random bytes are read at a time when making many
+// phrases.
+const sourceBlock = 4096
+
+// maxRejects is how many phrases in a row config.Reject may reject before
+// generation gives up, since something must be wrong by then.
+const maxRejects = 100
+
+// ErrTooManyRejects is returned when config.Reject rejects maxRejects
+// phrases in a row.
+var ErrTooManyRejects = errors.New("too many phrases rejected in a row")
+
+// makeNth makes phrase i of config from src, or from its own seeded stream
+// if config has a seed.
+func makeNth(config Config, i int, src *source) (Phrase, error) {
+	if config.Seed != nil {
+		src = newSource(seededReader(config.Seed, i), 64)
+	}
+	for tries := 0; tries < maxRejects; tries++ {
+		p, err := phraseFrom(src, config.Words(), config.Dict)
+		if err != nil {
+			return Phrase{}, err
+		}
+		if config.NoSeparator {
+			p.Phrase = strings.Join(p.Words, "")
+			p.Stats.Length = len(p.Phrase)
+		}
+		if config.Reject == nil {
+			return p, nil
+		}
+		reject, err := config.Reject(p)
+		if err != nil {
+			return Phrase{}, err
+		}
+		if !reject {
+			return p, nil
+		}
+		if Debug {
+			logger().Debug("rejected", "phrase", p.Phrase)
+		}
+	}
+	return Phrase{}, ErrTooManyRejects
+}
+
+// seededReader returns the ChaCha8 stream for phrase i, keyed by the
+// SHA-256 of seed and i.
+func seededReader(seed []byte, i int) io.Reader {
+	h := sha256.New()
+	h.Write(seed)
+	binary.Write(h, binary.BigEndian, uint64(i))
+	var key [32]byte
+	copy(key[:], h.Sum(nil))
+	return mrand.NewChaCha8(key)
+}
+
+type result struct {
+	p   Phrase
+	err error
+}
+
+type job struct {
+	i   int
+	out chan result
+}
+
+// parallel spreads phrases over workers goroutines. Each phrase has its own
+// result channel, queued in order in pending; since pending is bounded, at
+// most a few phrases per worker are held at any time.
+func parallel(config Config, workers int, yield func(Phrase, error) bool) {
+	jobs := make(chan job)
+	pending := make(chan chan result, 4*workers)
+	done := make(chan struct{})
+	defer close(done)
+
+	for w := 0; w < workers; w++ {
+		go func() {
+			src := newSource(rand.Reader, sourceBlock)
+			for j := range jobs {
+				p, err := makeNth(config, j.i, src)
+				j.out <- result{p, err}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(pending)
+		defer close(jobs)
+		for i := 0; i < config.NumPhrases; i++ {
+			out := make(chan result, 1)
+			select {
+			case pending <- out:
+			case <-done:
+				return
+			}
+			select {
+			case jobs <- job{i, out}:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for out := range pending {
+		r := <-out
+		if !yield(r.p, r.err) || r.err != nil {
+			return
+		}
+	}
+}
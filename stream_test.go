@@ -0,0 +1,83 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPhrases(t *testing.T) {
+	// 1e8 workers would run out of memory if they weren't capped
+	for _, workers := range []int{0, 1, 4, 1e8} {
+		conf := Config{NumPhrases: 100, NumWords: 3, Dict: Short, Workers: workers}
+		n := 0
+		for p, err := range Phrases(conf) {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(p.Words) != 3 || p.Dict != Short {
+				t.Errorf("unexpected phrase %+v", p)
+			}
+			n++
+		}
+		if n != 100 {
+			t.Errorf("workers %d: expected 100 phrases, got %d", workers, n)
+		}
+
+		n = 0
+		for range Phrases(conf) {
+			n++
+			if n == 10 {
+				break
+			}
+		}
+	}
+}
+
+func TestPhrasesSeeded(t *testing.T) {
+	collect := func(conf Config) []string {
+		var out []string
+		err := Stream(conf, func(i int, p Phrase) error {
+			if i != len(out) {
+				t.Errorf("expected index %d, got %d", len(out), i)
+			}
+			out = append(out, p.Phrase)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return out
+	}
+
+	conf := Config{NumPhrases: 50, NumBits: 64, Seed: []byte("fixtures")}
+	serial := collect(conf)
+	conf.Workers = 8
+	parallel := collect(conf)
+	for i := range serial {
+		if serial[i] != parallel[i] {
+			t.Errorf("phrase %d: %q != %q", i, serial[i], parallel[i])
+		}
+	}
+	if serial[0] == serial[1] {
+		t.Errorf("phrases repeat: %q", serial[0])
+	}
+
+	conf.Seed = []byte("other")
+	if other := collect(conf); other[0] == serial[0] {
+		t.Errorf("different seeds made the same phrase %q", other[0])
+	}
+
+	stop := errors.New("stop")
+	n := 0
+	err := Stream(conf, func(i int, p Phrase) error {
+		n++
+		if n == 3 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || n != 3 {
+		t.Errorf("expected to stop after 3, got %d %v", n, err)
+	}
+}
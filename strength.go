@@ -0,0 +1,636 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Match is a part of a password that a guesser would try as one piece.
+type Match struct {
+	// Pattern is dictionary, spatial, repeat, sequence, date or bruteforce.
+	Pattern string
+	// I and J are the first and last character of the match, counting
+	// characters from 0.
+	I, J    int
+	Token   string
+	Guesses float64
+
+	// Word is the list word a dictionary match stands for, with any l33t
+	// substitutions undone.
+	Word string
+	L33t bool
+	// Turns and Shifted are the changes of direction and shifted keys of
+	// a spatial match.
+	Turns, Shifted int
+	// Base is the part a repeat match repeats, Repeats times.
+	Base    string
+	Repeats int
+	// Year is the year of a date match.
+	Year int
+}
+
+// Strength is how guessable a password is, found by EstimateStrength.
+type Strength struct {
+	// Guesses is the estimated number of guesses an attacker who knows
+	// these patterns needs, and Bits is its log2.
+	Guesses float64
+	Bits    float64
+	// Score is 0 (too guessable) to 4 (very unguessable), as in zxcvbn.
+	Score int
+	// Sequence is the cheapest way found to guess the password, piece by
+	// piece.
+	Sequence    []Match
+	Warning     string
+	Suggestions []string
+}
+
+const (
+	// minGuessesSequence is added for every extra piece of a sequence, so
+	// that splitting a password into many small pieces isn't cheaper than
+	// it should be.
+	minGuessesSequence = 10000
+	bruteforceBase     = 10
+	minYearSpace       = 20
+)
+
+// referenceYear is the year dates and years are measured from.
+var referenceYear = time.Now().Year()
+
+// strengthWords maps every word of the built-in lists to the guesses it
+// takes to find it among them, the size of the smallest list it is in.
+var (
+	strengthWordsOnce sync.Once
+	strengthWords     map[string]float64
+	strengthMaxWord   int
+)
+
+func loadStrengthWords() {
+	strengthWords = make(map[string]float64)
+	for _, dict := range []Dictionary{Large, Short, Short2} {
+		l := List(dict)
+		for _, w := range l.words {
+			if g, ok := strengthWords[w]; !ok || float64(l.Len()) < g {
+				strengthWords[w] = float64(l.Len())
+			}
+			strengthMaxWord = max(strengthMaxWord, len([]rune(w)))
+		}
+	}
+}
+
+// EstimateStrength estimates how many guesses a password takes, in the
+// spirit of zxcvbn: it finds every EFF list word (also in l33t speak or
+// with capitals), keyboard pattern, repeat, sequence and date in it, and
+// takes the cheapest way to cover the password with them and brute force.
+// It works offline; the EFF lists are the dictionary.
+func EstimateStrength(password string) Strength {
+	strengthWordsOnce.Do(loadStrengthWords)
+	pw := []rune(password)
+	if len(pw) == 0 {
+		return Strength{Guesses: 1, Warning: "The password is empty.", Suggestions: []string{"Use a generated passphrase, such as dicewords -w 6."}}
+	}
+
+	var matches []Match
+	matches = append(matches, dictionaryMatches(pw)...)
+	matches = append(matches, spatialMatches(pw)...)
+	matches = append(matches, repeatMatches(pw)...)
+	matches = append(matches, sequenceMatches(pw)...)
+	matches = append(matches, dateMatches(pw)...)
+	for i := range matches {
+		m := &matches[i]
+		m.Token = string(pw[m.I : m.J+1])
+		if m.J-m.I+1 < len(pw) {
+			// a piece of a password is at least as hard as a short guess
+			if m.I == m.J {
+				m.Guesses = max(m.Guesses, 10)
+			} else {
+				m.Guesses = max(m.Guesses, 50)
+			}
+		}
+	}
+
+	s := Strength{}
+	s.Guesses, s.Sequence = cheapestSequence(pw, matches)
+	s.Bits = math.Log2(s.Guesses)
+	s.Score = strengthScore(s.Guesses)
+	s.Warning, s.Suggestions = strengthFeedback(s)
+	return s
+}
+
+func strengthScore(guesses float64) int {
+	switch {
+	case guesses < 1e3+5:
+		return 0
+	case guesses < 1e6+5:
+		return 1
+	case guesses < 1e8+5:
+		return 2
+	case guesses < 1e10+5:
+		return 3
+	}
+	return 4
+}
+
+// cheapestSequence finds the sequence of non-overlapping matches, with
+// brute force for the gaps, that covers pw in the fewest guesses. A
+// sequence of l pieces costs l! times the product of their guesses, since
+// the pieces can come in any order, plus minGuessesSequence^(l-1).
+func cheapestSequence(pw []rune, matches []Match) (float64, []Match) {
+	n := len(pw)
+	type best struct {
+		m  map[int]Match
+		pi map[int]float64
+		g  map[int]float64
+	}
+	opt := make([]best, n)
+	for k := range opt {
+		opt[k] = best{map[int]Match{}, map[int]float64{}, map[int]float64{}}
+	}
+	update := func(m Match, l int) {
+		k := m.J
+		pi := m.Guesses
+		if l > 1 {
+			pi *= opt[m.I-1].pi[l-1]
+		}
+		g := factorial(l)*pi + math.Pow(minGuessesSequence, float64(l-1))
+		for cl, cg := range opt[k].g {
+			if cl <= l && cg <= g {
+				return
+			}
+		}
+		opt[k].m[l], opt[k].pi[l], opt[k].g[l] = m, pi, g
+	}
+	bruteforce := func(i, j int) Match {
+		g := math.Pow(bruteforceBase, float64(j-i+1))
+		if j-i+1 < n {
+			g = max(g, 11)
+		}
+		return Match{Pattern: "bruteforce", I: i, J: j, Token: string(pw[i : j+1]), Guesses: g}
+	}
+
+	byEnd := make([][]Match, n)
+	for _, m := range matches {
+		byEnd[m.J] = append(byEnd[m.J], m)
+	}
+	for k := 0; k < n; k++ {
+		for _, m := range byEnd[k] {
+			if m.I == 0 {
+				update(m, 1)
+				continue
+			}
+			for l := range opt[m.I-1].m {
+				update(m, l+1)
+			}
+		}
+		update(bruteforce(0, k), 1)
+		for i := 1; i <= k; i++ {
+			m := bruteforce(i, k)
+			for l, last := range opt[i-1].m {
+				// two brute force pieces in a row are one piece
+				if last.Pattern != "bruteforce" {
+					update(m, l+1)
+				}
+			}
+		}
+	}
+
+	bestL, bestG := 0, math.Inf(1)
+	for l, g := range opt[n-1].g {
+		if g < bestG || (g == bestG && l < bestL) {
+			bestL, bestG = l, g
+		}
+	}
+	seq := make([]Match, bestL)
+	for k, l := n-1, bestL; l > 0; l-- {
+		m := opt[k].m[l]
+		seq[l-1] = m
+		k = m.I - 1
+	}
+	return bestG, seq
+}
+
+// l33tTable maps characters to the letters they are used for.
+var l33tTable = map[rune][]rune{
+	'4': {'a'}, '@': {'a'}, '8': {'b'}, '(': {'c'}, '{': {'c'}, '[': {'c'}, '<': {'c'},
+	'3': {'e'}, '6': {'g'}, '9': {'g'}, '1': {'i', 'l'}, '!': {'i'}, '|': {'i', 'l'},
+	'0': {'o'}, '$': {'s'}, '5': {'s'}, '7': {'t'}, '+': {'t'}, '%': {'x'}, '2': {'z'},
+}
+
+// maxL33tVariants caps how many ways a token's l33t characters are read.
+const maxL33tVariants = 64
+
+func dictionaryMatches(pw []rune) []Match {
+	lower := make([]rune, len(pw))
+	for i, r := range pw {
+		lower[i] = unicode.ToLower(r)
+	}
+	var matches []Match
+	for i := range lower {
+		for j := i + 2; j < len(lower) && j-i < strengthMaxWord; j++ {
+			token := lower[i : j+1]
+			if g, ok := strengthWords[string(token)]; ok {
+				matches = append(matches, Match{
+					Pattern: "dictionary", I: i, J: j, Word: string(token),
+					Guesses: g * upperVariations(pw[i:j+1]),
+				})
+			}
+			for _, v := range l33tVariants(token) {
+				if g, ok := strengthWords[string(v.word)]; ok {
+					matches = append(matches, Match{
+						Pattern: "dictionary", I: i, J: j, Word: string(v.word), L33t: true,
+						Guesses: g * upperVariations(pw[i:j+1]) * l33tVariations(token, v.subs),
+					})
+				}
+			}
+		}
+	}
+	return matches
+}
+
+type l33tVariant struct {
+	word []rune
+	subs map[rune]rune
+}
+
+// l33tVariants returns the ways to read token's l33t characters as
+// letters, or none if it has no l33t characters.
+func l33tVariants(token []rune) []l33tVariant {
+	variants := []l33tVariant{{word: append([]rune(nil), token...), subs: map[rune]rune{}}}
+	found := false
+	for i, r := range token {
+		letters, ok := l33tTable[r]
+		if !ok {
+			continue
+		}
+		found = true
+		var next []l33tVariant
+		for _, v := range variants {
+			for _, letter := range letters {
+				if prev, ok := v.subs[r]; ok && prev != letter {
+					// a character stands for one letter throughout
+					continue
+				}
+				w := append([]rune(nil), v.word...)
+				w[i] = letter
+				subs := make(map[rune]rune, len(v.subs)+1)
+				for k, s := range v.subs {
+					subs[k] = s
+				}
+				subs[r] = letter
+				next = append(next, l33tVariant{w, subs})
+			}
+		}
+		if len(next) > maxL33tVariants {
+			next = next[:maxL33tVariants]
+		}
+		variants = next
+	}
+	if !found {
+		return nil
+	}
+	return variants
+}
+
+// upperVariations is how many ways of capitalizing a word a guesser
+// tries before reaching token's: lower case is free, a capital at the
+// start or end or all capitals double the guesses, and anything else costs
+// the ways to place that many capitals.
+func upperVariations(token []rune) float64 {
+	upper, lower := 0, 0
+	for _, r := range token {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+	if upper == 0 {
+		return 1
+	}
+	if lower == 0 || (upper == 1 && (unicode.IsUpper(token[0]) || unicode.IsUpper(token[len(token)-1]))) {
+		return 2
+	}
+	return sumBinomials(upper+lower, min(upper, lower))
+}
+
+// l33tVariations is how many ways of substituting letters a guesser tries
+// before reaching token's.
+func l33tVariations(token []rune, subs map[rune]rune) float64 {
+	v := 1.0
+	for sub, letter := range subs {
+		s, u := 0, 0
+		for _, r := range token {
+			switch r {
+			case sub:
+				s++
+			case letter:
+				u++
+			}
+		}
+		if s == 0 || u == 0 {
+			v *= 2
+			continue
+		}
+		v *= sumBinomials(s+u, min(s, u))
+	}
+	return v
+}
+
+// sumBinomials is C(n, 1) + ... + C(n, k).
+func sumBinomials(n, k int) float64 {
+	sum := 0.0
+	for i := 1; i <= k; i++ {
+		sum += binomial(n, i)
+	}
+	return sum
+}
+
+func binomial(n, k int) float64 {
+	if k < 0 || k > n {
+		return 0
+	}
+	return math.Round(math.Exp(logBinomial(n, k)))
+}
+
+func factorial(n int) float64 {
+	f := 1.0
+	for i := 2; i <= n; i++ {
+		f *= float64(i)
+	}
+	return f
+}
+
+// spatialMatches finds runs of three or more neighbouring keys, like qwerty
+// or zxcvfr.
+func spatialMatches(pw []rune) []Match {
+	var matches []Match
+	for i := 0; i < len(pw)-1; {
+		j := i + 1
+		lastDir, turns := -1, 0
+		for ; j < len(pw); j++ {
+			dir := keyDirection(pw[j-1], pw[j])
+			if dir < 0 {
+				break
+			}
+			if dir != lastDir {
+				turns++
+				lastDir = dir
+			}
+		}
+		if j-i >= 3 {
+			shifted := 0
+			for _, r := range pw[i:j] {
+				if qwertyKeys[r].shifted {
+					shifted++
+				}
+			}
+			matches = append(matches, Match{
+				Pattern: "spatial", I: i, J: j - 1, Turns: turns, Shifted: shifted,
+				Guesses: spatialGuesses(j-i, turns, shifted),
+			})
+		}
+		i = j
+	}
+	return matches
+}
+
+// spatialGuesses counts the keyboard patterns of up to length keys and
+// turns changes of direction, from any key, and the ways to shift them.
+func spatialGuesses(length, turns, shifted int) float64 {
+	g := 0.0
+	for i := 2; i <= length; i++ {
+		for j := 1; j <= min(turns, i-1); j++ {
+			g += binomial(i-1, j-1) * float64(qwertyStarts) * math.Pow(qwertyDegree, float64(j))
+		}
+	}
+	if shifted > 0 {
+		unshifted := length - shifted
+		if unshifted == 0 {
+			g *= 2
+		} else {
+			g *= sumBinomials(length, min(shifted, unshifted))
+		}
+	}
+	return g
+}
+
+// repeatMatches finds a part repeated two or more times, like aaa or
+// abcabc, taking the one covering most characters from each position.
+func repeatMatches(pw []rune) []Match {
+	var matches []Match
+	for i := 0; i < len(pw); {
+		bestLen, bestBase, bestCount := 0, 0, 0
+		for base := 1; i+2*base <= len(pw); base++ {
+			count := 1
+			for i+(count+1)*base <= len(pw) && string(pw[i+count*base:i+(count+1)*base]) == string(pw[i:i+base]) {
+				count++
+			}
+			if count >= 2 && count*base > bestLen {
+				bestLen, bestBase, bestCount = count*base, base, count
+			}
+		}
+		if bestLen == 0 {
+			i++
+			continue
+		}
+		base := string(pw[i : i+bestBase])
+		matches = append(matches, Match{
+			Pattern: "repeat", I: i, J: i + bestLen - 1, Base: base, Repeats: bestCount,
+			Guesses: EstimateStrength(base).Guesses * float64(bestCount),
+		})
+		i += bestLen
+	}
+	return matches
+}
+
+// sequenceMatches finds three or more characters with the same small step
+// between them, like abc, 2468 or zyx.
+func sequenceMatches(pw []rune) []Match {
+	var matches []Match
+	for i := 0; i+2 < len(pw); {
+		delta := pw[i+1] - pw[i]
+		if delta == 0 || delta > 5 || delta < -5 {
+			i++
+			continue
+		}
+		j := i + 1
+		for j+1 < len(pw) && pw[j+1]-pw[j] == delta {
+			j++
+		}
+		if j-i+1 >= 3 {
+			first := pw[i]
+			base := 26.0
+			switch {
+			case strings.ContainsRune("aAzZ019", first):
+				base = 4
+			case unicode.IsDigit(first):
+				base = 10
+			}
+			if delta < 0 {
+				base *= 2
+			}
+			matches = append(matches, Match{Pattern: "sequence", I: i, J: j, Guesses: base * float64(j-i+1)})
+		}
+		i = j
+	}
+	return matches
+}
+
+var dateWithSeparator = regexp.MustCompile(`^(\d{1,4})([\s/\\_.-])(\d{1,2})([\s/\\_.-])(\d{1,4})$`)
+
+// dateMatches finds years from 1900 to 2050 and dates of day, month and
+// year in any usual order, with or without separators.
+func dateMatches(pw []rune) []Match {
+	var matches []Match
+	for i := range pw {
+		for j := i + 3; j < len(pw) && j-i < 10; j++ {
+			token := string(pw[i : j+1])
+			if y, ok := dateYear(token); ok {
+				g := yearSpace(y) * 365
+				if !isDigits(token) {
+					g *= 4
+				} else if len(token) == 4 {
+					g = yearSpace(y)
+				}
+				matches = append(matches, Match{Pattern: "date", I: i, J: j, Year: y, Guesses: g})
+			}
+		}
+	}
+	return matches
+}
+
+// dateYear returns the year of token if it is a year or a date.
+func dateYear(token string) (int, bool) {
+	var parts []string
+	switch {
+	case isDigits(token) && len(token) == 4:
+		y, _ := strconv.Atoi(token)
+		return y, y >= 1900 && y <= 2050
+	case isDigits(token) && len(token) == 6:
+		parts = []string{token[:2], token[2:4], token[4:]}
+	case isDigits(token) && len(token) == 8:
+		// day month year, month day year or year month day
+		for _, p := range [][]string{
+			{token[:2], token[2:4], token[4:]},
+			{token[:4], token[4:6], token[6:]},
+		} {
+			if y, ok := dateParts(p); ok {
+				return y, true
+			}
+		}
+		return 0, false
+	default:
+		m := dateWithSeparator.FindStringSubmatch(token)
+		if m == nil || m[2] != m[4] {
+			return 0, false
+		}
+		parts = []string{m[1], m[3], m[5]}
+	}
+	return dateParts(parts)
+}
+
+// dateParts reads three numbers as day month year, month day year or year
+// month day, with two or four digit years.
+func dateParts(p []string) (int, bool) {
+	n := make([]int, 3)
+	for i, s := range p {
+		n[i], _ = strconv.Atoi(s)
+	}
+	for _, order := range [][3]int{{0, 1, 2}, {1, 0, 2}, {2, 1, 0}} {
+		d, m, ys := n[order[0]], n[order[1]], p[order[2]]
+		y := n[order[2]]
+		switch len(ys) {
+		case 2:
+			if y > 50 {
+				y += 1900
+			} else {
+				y += 2000
+			}
+		case 4:
+		default:
+			continue
+		}
+		if d >= 1 && d <= 31 && m >= 1 && m <= 12 && y >= 1000 && y <= 2050 {
+			return y, true
+		}
+	}
+	return 0, false
+}
+
+func yearSpace(y int) float64 {
+	return float64(max(abs(y-referenceYear), minYearSpace))
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// strengthFeedback explains a weak password by its longest guessable piece.
+func strengthFeedback(s Strength) (string, []string) {
+	if s.Score > 2 {
+		return "", nil
+	}
+	suggestions := []string{
+		"Add another word or two. Uncommon words are better.",
+		"Or use a generated passphrase, such as dicewords -w 6.",
+	}
+	var longest *Match
+	for i := range s.Sequence {
+		m := &s.Sequence[i]
+		if m.Pattern != "bruteforce" && (longest == nil || m.J-m.I > longest.J-longest.I) {
+			longest = m
+		}
+	}
+	if longest == nil {
+		return "", suggestions
+	}
+
+	warning := ""
+	switch longest.Pattern {
+	case "dictionary":
+		warning = "Words from diceware lists are easy to guess unless several are picked at random."
+		if len(s.Sequence) == 1 {
+			warning = "A word by itself is easy to guess."
+		}
+		token := []rune(longest.Token)
+		switch {
+		case unicode.IsUpper(token[0]) && upperVariations(token) == 2:
+			suggestions = append(suggestions, "Capitalization doesn't help very much.")
+		case upperVariations(token) == 2:
+			suggestions = append(suggestions, "All capitals is almost as easy to guess as all lower case.")
+		}
+		if longest.L33t {
+			suggestions = append(suggestions, "Predictable substitutions like '@' instead of 'a' don't help very much.")
+		}
+	case "spatial":
+		warning = "Short keyboard patterns are easy to guess."
+		if longest.Turns == 1 {
+			warning = "Straight rows of keys are easy to guess."
+		}
+		suggestions = append(suggestions, "Use a longer keyboard pattern with more turns.")
+	case "repeat":
+		warning = `Repeats like "abcabcabc" are only slightly harder to guess than "abc".`
+		if len([]rune(longest.Base)) == 1 {
+			warning = `Repeats like "aaa" are easy to guess.`
+		}
+		suggestions = append(suggestions, "Avoid repeated words and characters.")
+	case "sequence":
+		warning = "Sequences like abc or 6543 are easy to guess."
+		suggestions = append(suggestions, "Avoid sequences.")
+	case "date":
+		warning = "Dates and years are often easy to guess."
+		suggestions = append(suggestions, "Avoid dates and years that are associated with you.")
+	}
+	return warning, suggestions
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"testing"
+)
+
+func TestEstimateStrength(t *testing.T) {
+	for _, test := range []struct {
+		password string
+		pattern  string
+		maxScore int
+	}{
+		{"abacus", "dictionary", 1},
+		{"Ab4cus", "dictionary", 1},
+		{"qwertyuiop", "spatial", 1},
+		{"aaaaaaaa", "repeat", 0},
+		{"abcdefg", "sequence", 0},
+		{"1990", "date", 0},
+		{"25.12.1990", "date", 1},
+	} {
+		s := EstimateStrength(test.password)
+		if len(s.Sequence) != 1 || s.Sequence[0].Pattern != test.pattern {
+			t.Errorf("%s: expected one %s match, got %+v", test.password, test.pattern, s.Sequence)
+		}
+		if s.Score > test.maxScore {
+			t.Errorf("%s: expected score at most %d, got %d", test.password, test.maxScore, s.Score)
+		}
+		if s.Warning == "" || len(s.Suggestions) == 0 {
+			t.Errorf("%s: expected feedback", test.password)
+		}
+	}
+
+	s := EstimateStrength("Ab4cus")
+	if m := s.Sequence[0]; !m.L33t || m.Word != "abacus" || m.Guesses != 7776*2*2 {
+		t.Errorf("unexpected match %+v", m)
+	}
+
+	// a generated phrase is as strong as its list says, or close
+	p, err := MakePhrase(6, Large)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s = EstimateStrength(p.Phrase)
+	if s.Score != 4 || s.Bits < p.Stats.NumBits-1 {
+		t.Errorf("%d words: unexpected score %d, %.1f bits", len(p.Words), s.Score, s.Bits)
+	}
+	if s.Warning != "" || s.Suggestions != nil {
+		t.Errorf("unexpected feedback %q %v", s.Warning, s.Suggestions)
+	}
+
+	s = EstimateStrength("")
+	if s.Score != 0 || s.Guesses != 1 {
+		t.Errorf("unexpected strength for empty password %+v", s)
+	}
+
+	random := EstimateStrength("xkq7#Lm2pVw9")
+	if random.Score != 4 || len(random.Sequence) != 1 || random.Sequence[0].Pattern != "bruteforce" {
+		t.Errorf("unexpected strength %+v", random)
+	}
+}
+
+func TestKeyboard(t *testing.T) {
+	for _, test := range []struct {
+		a, b rune
+		near bool
+	}{
+		{'q', 'w', true}, {'q', 'a', true}, {'w', 'a', true}, {'1', 'q', true},
+		{'a', 'Z', true}, {'q', 's', false}, {'a', 'l', false}, {'a', 'a', false},
+	} {
+		if near := keyDirection(test.a, test.b) >= 0; near != test.near {
+			t.Errorf("%c %c: expected %v", test.a, test.b, test.near)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// maxTypoDistance is the most edits a suggestion may be from what was
+// typed.
+const maxTypoDistance = 2
+
+// WordCheck is one word of a phrase checked by CheckPhrase.
+type WordCheck struct {
+	Token string
+	Known bool
+	// Suggestions are the closest words of the list for an unknown token,
+	// best first: the word with the same unique prefix, if the list has
+	// them, then words by edit distance.
+	Suggestions []string
+}
+
+// CheckPhrase splits phrase into words and checks each against dict,
+// suggesting up to maxSuggestions words for the ones not in it. Case is
+// ignored, and words typed without a space between them are split apart.
+func CheckPhrase(phrase string, dict Dictionary, maxSuggestions int) []WordCheck {
+	l := List(dict)
+	var checks []WordCheck
+	for _, token := range strings.Fields(strings.ToLower(phrase)) {
+		if _, ok := l.Find(token); ok {
+			checks = append(checks, WordCheck{Token: token, Known: true})
+			continue
+		}
+		if words := l.split(token); words != nil {
+			for _, w := range words {
+				checks = append(checks, WordCheck{Token: w, Known: true})
+			}
+			continue
+		}
+		checks = append(checks, WordCheck{Token: token, Suggestions: l.Suggest(token, maxSuggestions)})
+	}
+	return checks
+}
+
+// Suggest returns up to n words of the list that token was likely meant to
+// be, best first.
+func (l *WordList) Suggest(token string, n int) []string {
+	if n < 1 {
+		return nil
+	}
+	var out []string
+	if l.prefixLen > 0 && len(token) >= l.prefixLen {
+		if e, ok := l.FindPrefix(token[:l.prefixLen]); ok {
+			out = append(out, e.Word)
+		}
+	}
+
+	type candidate struct {
+		word string
+		dist int
+	}
+	var cands []candidate
+	for _, w := range l.words {
+		if abs(len(w)-len(token)) > maxTypoDistance || (len(out) > 0 && w == out[0]) {
+			continue
+		}
+		if d := typoDistance(token, w); d <= maxTypoDistance {
+			cands = append(cands, candidate{w, d})
+		}
+	}
+	sort.SliceStable(cands, func(i, j int) bool { return cands[i].dist < cands[j].dist })
+	for _, c := range cands {
+		if len(out) == n {
+			break
+		}
+		out = append(out, c.word)
+	}
+	return out
+}
+
+// typoDistance is the edit distance between a and b counting a swap of
+// two neighbouring letters as one edit, the optimal string alignment
+// distance, since a swap is one of the most common typos.
+func typoDistance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+// split returns the words of the list that run together make token, or nil
+// if there are none.
+func (l *WordList) split(token string) []string {
+	// from[i] is where the last word of a split of token[:i] starts
+	from := make([]int, len(token)+1)
+	for i := range from {
+		from[i] = -1
+	}
+	from[0] = 0
+	for i := 0; i < len(token); i++ {
+		if from[i] < 0 {
+			continue
+		}
+		for j := i + 1; j <= len(token); j++ {
+			if _, ok := l.index[token[i:j]]; ok && from[j] < 0 {
+				from[j] = i
+			}
+		}
+	}
+	if from[len(token)] < 0 {
+		return nil
+	}
+	var words []string
+	for i := len(token); i > 0; i = from[i] {
+		words = append(words, token[from[i]:i])
+	}
+	slices.Reverse(words)
+	return words
+}
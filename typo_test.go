@@ -0,0 +1,55 @@
+// Copyright 2026 Timothy Ham
+package dicewords
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckPhrase(t *testing.T) {
+	checks := CheckPhrase("Abacus siad  abacuszoom zzzzzzzzzz", Large, 3)
+	var tokens []string
+	for _, c := range checks {
+		tokens = append(tokens, c.Token)
+	}
+	if !reflect.DeepEqual(tokens, []string{"abacus", "siad", "abacus", "zoom", "zzzzzzzzzz"}) {
+		t.Fatalf("unexpected tokens %v", tokens)
+	}
+	if !checks[0].Known || checks[1].Known || !checks[2].Known || !checks[3].Known || checks[4].Known {
+		t.Errorf("unexpected checks %+v", checks)
+	}
+	if len(checks[1].Suggestions) == 0 || checks[1].Suggestions[0] != "said" {
+		t.Errorf("expected said first, got %v", checks[1].Suggestions)
+	}
+	if len(checks[4].Suggestions) != 0 {
+		t.Errorf("unexpected suggestions %v", checks[4].Suggestions)
+	}
+
+	// the unique prefix wins even when the rest is far off
+	checks = CheckPhrase("zucxxxxxx", Short2, 3)
+	if len(checks) != 1 || len(checks[0].Suggestions) == 0 || checks[0].Suggestions[0] != "zucchini" {
+		t.Errorf("expected zucchini first, got %+v", checks)
+	}
+
+	checks = CheckPhrase("siad", Large, 0)
+	if checks[0].Suggestions != nil {
+		t.Errorf("expected no suggestions, got %v", checks[0].Suggestions)
+	}
+}
+
+func TestTypoDistance(t *testing.T) {
+	for _, test := range []struct {
+		a, b string
+		want int
+	}{
+		{"siad", "said", 1},
+		{"tabel", "table", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+		{"same", "same", 0},
+	} {
+		if d := typoDistance(test.a, test.b); d != test.want {
+			t.Errorf("%s %s: expected %d, got %d", test.a, test.b, test.want, d)
+		}
+	}
+}
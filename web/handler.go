@@ -0,0 +1,337 @@
+// Copyright 2026 Timothy Ham
+
+// Package web serves dicewords passphrases over HTTP. The same handler backs
+// the CGI binary and the standalone server.
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/timothyham/dicewords"
+)
+
+// Formats understood by the handler, as used in ?format=.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatHTML = "html"
+)
+
+// offers lists the representations in order of preference when the client
+// accepts several equally. Plain text comes first so that curl, which sends
+// Accept: */*, gets one phrase per line.
+var offers = []struct {
+	format    string
+	mediaType string
+}{
+	{FormatText, "text/plain"},
+	{FormatJSON, "application/json"},
+	{FormatHTML, "text/html"},
+}
+
+// Handler generates passphrases and writes them as plain text, JSON or HTML.
+// Config is the default; a request may change it with ?p= (phrases),
+// ?w= (words), ?b= (bits) and ?dict=, within MaxPhrases and MaxWords.
+// Crack estimates against Attackers are in JSON, and in the other formats
+// if Verbose is set.
+type Handler struct {
+	Config     dicewords.Config
+	NumApple   int
+	Verbose    bool
+	MaxPhrases int
+	MaxWords   int
+	Attackers  []dicewords.Attacker
+	Metrics    *Metrics
+}
+
+func NewHandler(config dicewords.Config) *Handler {
+	return &Handler{Config: config, NumApple: 5, MaxPhrases: 20, MaxWords: dicewords.MaxWords, Attackers: dicewords.DefaultAttackers()}
+}
+
+// Phrase is one generated passphrase with its stats. It is the unit of every
+// representation the handler produces.
+type Phrase struct {
+	Phrase   string                    `json:"phrase"`
+	Bits     float64                   `json:"bits"`
+	Length   int                       `json:"length"`
+	NumChars int                       `json:"numChars"`
+	Crack    []dicewords.CrackEstimate `json:"crack"`
+}
+
+// Page is everything one request produces.
+type Page struct {
+	Phrases []Phrase `json:"phrases"`
+	Apple   []Phrase `json:"apple"`
+	Verbose bool     `json:"-"`
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		h.error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	format, err := negotiate(r)
+	if err != nil {
+		h.error(w, err.Error(), http.StatusNotAcceptable)
+		return
+	}
+
+	config, err := h.requestConfig(r)
+	if err != nil {
+		h.error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	start := time.Now()
+	page := h.generate(config)
+	h.Metrics.request(format, config.Dict, time.Since(start))
+	if format == FormatJSON || h.Verbose {
+		addCrack(page.Phrases, h.Attackers)
+		addCrack(page.Apple, h.Attackers)
+	}
+
+	w.Header().Set("Vary", "Accept")
+	w.Header().Set("Cache-Control", "no-store")
+	switch format {
+	case FormatJSON:
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(w).Encode(page)
+	case FormatHTML:
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		htmlTemplate.Execute(w, page)
+	default:
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		writeText(w, page)
+	}
+}
+
+func (h *Handler) error(w http.ResponseWriter, msg string, code int) {
+	h.Metrics.error(code)
+	http.Error(w, msg, code)
+}
+
+// requestConfig applies the request's parameters to h.Config and checks the
+// result against the limits.
+func (h *Handler) requestConfig(r *http.Request) (dicewords.Config, error) {
+	config := h.Config
+	q := r.URL.Query()
+
+	if v := q.Get("dict"); v != "" {
+		dict, err := dicewords.ParseDictionary(v)
+		if err != nil {
+			return config, err
+		}
+		config.Dict = dict
+	}
+	for _, p := range []struct {
+		name string
+		dst  *int
+	}{{"p", &config.NumPhrases}, {"w", &config.NumWords}, {"b", &config.NumBits}} {
+		v := q.Get(p.name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return config, fmt.Errorf("bad %s=%q", p.name, v)
+		}
+		*p.dst = n
+	}
+	if q.Get("b") != "" && q.Get("w") == "" {
+		// an explicit bit target overrides the default word count
+		config.NumWords = 0
+	}
+
+	return config, h.checkLimits(config)
+}
+
+// checkLimits checks config against MaxPhrases and MaxWords.
+func (h *Handler) checkLimits(config dicewords.Config) error {
+	if config.NumPhrases > h.MaxPhrases {
+		return fmt.Errorf("at most %d phrases per request", h.MaxPhrases)
+	}
+	if n := config.Words(); n < 1 || n > h.MaxWords {
+		return fmt.Errorf("at most %d words per phrase", h.MaxWords)
+	}
+	return nil
+}
+
+func (h *Handler) generate(config dicewords.Config) Page {
+	page := Page{Verbose: h.Verbose}
+
+	phrases, stats := dicewords.MakeWords(config)
+	page.Phrases = makePhrases(phrases, stats)
+
+	if h.NumApple > 0 {
+		phrases, stats = dicewords.MakeApple(dicewords.Config{NumPhrases: h.NumApple, AppleStyle: true}, false)
+		page.Apple = makePhrases(phrases, stats)
+	}
+	return page
+}
+
+func makePhrases(phrases []string, stats []dicewords.Stats) []Phrase {
+	out := make([]Phrase, len(phrases))
+	for i, phrase := range phrases {
+		out[i] = Phrase{
+			Phrase:   phrase,
+			Bits:     stats[i].NumBits,
+			Length:   stats[i].Length,
+			NumChars: stats[i].NumChars,
+		}
+	}
+	return out
+}
+
+// addCrack estimates cracking each of phrases for attackers.
+func addCrack(phrases []Phrase, attackers []dicewords.Attacker) {
+	for i := range phrases {
+		phrases[i].Crack = dicewords.EstimateCrack(phrases[i].Bits, attackers)
+	}
+}
+
+func (p Phrase) Stats() dicewords.Stats {
+	return dicewords.Stats{NumBits: p.Bits, Length: p.Length, NumChars: p.NumChars}
+}
+
+func writeTextPhrase(w http.ResponseWriter, p Phrase, verbose bool) {
+	fmt.Fprintf(w, "%s\n", p.Phrase)
+	if !verbose {
+		return
+	}
+	fmt.Fprintf(w, "    %s\n", dicewords.PrintStats(p.Stats()))
+	for _, e := range p.Crack {
+		fmt.Fprintf(w, "    %s\n", dicewords.PrintCrack(e))
+	}
+}
+
+func writeText(w http.ResponseWriter, page Page) {
+	for _, p := range page.Phrases {
+		writeTextPhrase(w, p, page.Verbose)
+	}
+	if len(page.Apple) == 0 {
+		return
+	}
+	fmt.Fprintln(w)
+	for _, p := range page.Apple {
+		writeTextPhrase(w, p, page.Verbose)
+	}
+}
+
+// negotiate picks a format from ?format= or, failing that, the Accept header.
+func negotiate(r *http.Request) (string, error) {
+	if f := r.URL.Query().Get("format"); f != "" {
+		switch strings.ToLower(f) {
+		case "text", "txt", "plain":
+			return FormatText, nil
+		case "json":
+			return FormatJSON, nil
+		case "html":
+			return FormatHTML, nil
+		}
+		return "", fmt.Errorf("unknown format %q", f)
+	}
+
+	accept := r.Header.Get("Accept")
+	if strings.TrimSpace(accept) == "" {
+		return FormatText, nil
+	}
+
+	ranges := parseAccept(accept)
+	best, bestQ := "", 0.0
+	for _, offer := range offers {
+		q := quality(ranges, offer.mediaType)
+		if q > bestQ {
+			best, bestQ = offer.format, q
+		}
+	}
+	if best == "" {
+		return "", fmt.Errorf("none of text/plain, application/json or text/html is acceptable")
+	}
+	return best, nil
+}
+
+type mediaRange struct {
+	typ, subtype string
+	q            float64
+}
+
+func parseAccept(accept string) []mediaRange {
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			// mime rejects a bare "*"; treat it as */*
+			if strings.TrimSpace(part) != "*" {
+				continue
+			}
+			mediaType = "*/*"
+		}
+		typ, subtype, ok := strings.Cut(mediaType, "/")
+		if !ok {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil && f >= 0 && f <= 1 {
+				q = f
+			}
+		}
+		ranges = append(ranges, mediaRange{typ, subtype, q})
+	}
+	return ranges
+}
+
+// quality returns the q value of the most specific range matching mediaType.
+func quality(ranges []mediaRange, mediaType string) float64 {
+	typ, subtype, _ := strings.Cut(mediaType, "/")
+	q, specificity := 0.0, -1
+	for _, r := range ranges {
+		s := -1
+		switch {
+		case r.typ == typ && r.subtype == subtype:
+			s = 2
+		case r.typ == typ && r.subtype == "*":
+			s = 1
+		case r.typ == "*" && r.subtype == "*":
+			s = 0
+		}
+		if s > specificity {
+			q, specificity = r.q, s
+		}
+	}
+	return q
+}
+
+var htmlTemplate = template.Must(template.New("page").Funcs(template.FuncMap{
+	"printStats": dicewords.PrintStats,
+	"printCrack": dicewords.PrintCrack,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Dicewords</title>
+</head>
+<body>
+{{with .Phrases}}<p>Each line of random words is about {{(index . 0).Bits}} bits</p>
+<p>
+{{range .}}{{.Phrase}}<br>
+{{if $.Verbose}}&nbsp;&nbsp;&nbsp;&nbsp;{{.Stats | printStats}}<br>
+{{range .Crack}}&nbsp;&nbsp;&nbsp;&nbsp;{{printCrack .}}<br>
+{{end}}{{end}}{{end}}</p>
+{{end}}{{with .Apple}}<p>Apple style passwords with about {{(index . 0).Bits}} bits</p>
+<p>
+{{range .}}{{.Phrase}}<br>
+{{if $.Verbose}}&nbsp;&nbsp;&nbsp;&nbsp;{{.Stats | printStats}}<br>
+{{range .Crack}}&nbsp;&nbsp;&nbsp;&nbsp;{{printCrack .}}<br>
+{{end}}{{end}}{{end}}</p>
+{{end}}</body>
+</html>
+`))
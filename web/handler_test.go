@@ -0,0 +1,171 @@
+// Copyright 2026 Timothy Ham
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/timothyham/dicewords"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		url    string
+		accept string
+		want   string
+	}{
+		{"/", "", FormatText},
+		{"/", "*/*", FormatText},
+		{"/", "application/json", FormatJSON},
+		{"/", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", FormatHTML},
+		{"/", "text/*;q=0.5, application/json;q=0.9", FormatJSON},
+		{"/", "text/plain;q=0, */*", FormatJSON},
+		{"/?format=html", "application/json", FormatHTML},
+		{"/?format=txt", "text/html", FormatText},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest("GET", test.url, nil)
+		if test.accept != "" {
+			r.Header.Set("Accept", test.accept)
+		}
+		got, err := negotiate(r)
+		if err != nil {
+			t.Errorf("%s %q: unexpected error %v", test.url, test.accept, err)
+		}
+		if got != test.want {
+			t.Errorf("%s %q: expected %s, got %s", test.url, test.accept, test.want, got)
+		}
+	}
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept", "image/png")
+	if _, err := negotiate(r); err == nil {
+		t.Errorf("expected error, got none")
+	}
+	r = httptest.NewRequest("GET", "/?format=xml", nil)
+	if _, err := negotiate(r); err == nil {
+		t.Errorf("expected error, got none")
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	conf := dicewords.MakeConfig()
+	conf.NumPhrases = 3
+	h := NewHandler(conf)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("unexpected content type %s", ct)
+	}
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if len(lines) != 3+1+5 {
+		t.Errorf("unexpected %d lines: %v", len(lines), lines)
+	}
+	if len(strings.Split(lines[0], " ")) != 5 {
+		t.Errorf("unexpected phrase %q", lines[0])
+	}
+
+	r = httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept", "application/json")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	var page Page
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Phrases) != 3 || len(page.Apple) != 5 {
+		t.Errorf("unexpected page %+v", page)
+	}
+	if page.Phrases[0].Bits != 64.6 || page.Phrases[0].Length != len(page.Phrases[0].Phrase) {
+		t.Errorf("unexpected stats %+v", page.Phrases[0])
+	}
+	if len(page.Phrases[0].Crack) != len(h.Attackers) || page.Phrases[0].Crack[0].Seconds <= 0 {
+		t.Errorf("unexpected crack estimates %+v", page.Phrases[0].Crack)
+	}
+
+	r = httptest.NewRequest("GET", "/?format=html", nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("unexpected content type %s", ct)
+	}
+	if !strings.Contains(w.Body.String(), "64.6 bits") {
+		t.Errorf("unexpected body %s", w.Body.String())
+	}
+
+	r = httptest.NewRequest("POST", "/", nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", w.Code)
+	}
+}
+
+func TestRequestConfig(t *testing.T) {
+	h := NewHandler(dicewords.MakeConfig())
+	h.MaxPhrases = 10
+	h.MaxWords = 8
+
+	r := httptest.NewRequest("GET", "/?p=2&w=7&dict=short2", nil)
+	conf, err := h.requestConfig(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conf.NumPhrases != 2 || conf.NumWords != 7 || conf.Dict != dicewords.Short2 {
+		t.Errorf("unexpected config %+v", conf)
+	}
+
+	r = httptest.NewRequest("GET", "/?b=80", nil)
+	conf, err = h.requestConfig(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conf.NumWords != 0 || conf.NumBits != 80 {
+		t.Errorf("unexpected config %+v", conf)
+	}
+
+	for _, url := range []string{"/?p=11", "/?w=9", "/?b=104", "/?p=-1", "/?w=x", "/?dict=klingon"} {
+		r = httptest.NewRequest("GET", url, nil)
+		if _, err := h.requestConfig(r); err == nil {
+			t.Errorf("%s: expected error, got none", url)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/?p=1000000", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestBitsBeyondWords(t *testing.T) {
+	h := NewHandler(dicewords.MakeConfig())
+	for _, url := range []string{"/?b=260&p=2", "/?dict=short&b=210", "/?format=json&b=1000"} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d %q", url, w.Code, w.Body)
+		}
+	}
+
+	// 20 words, the most allowed, are enough for these
+	for _, url := range []string{"/?format=json&b=250&p=1", "/?format=json&dict=short&b=200&p=1"} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d %q", url, w.Code, w.Body)
+		}
+		var page Page
+		if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+			t.Fatal(err)
+		}
+		if len(page.Phrases) != 1 || len(strings.Fields(page.Phrases[0].Phrase)) != 20 || page.Phrases[0].Bits < 200 {
+			t.Errorf("%s: unexpected phrases %+v", url, page.Phrases)
+		}
+	}
+}
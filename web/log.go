@@ -0,0 +1,44 @@
+// Copyright 2026 Timothy Ham
+package web
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/timothyham/dicewords"
+)
+
+// logger shares dicewords.Logger, so one setting covers the whole server.
+func logger() *slog.Logger {
+	if dicewords.Logger != nil {
+		return dicewords.Logger
+	}
+	return slog.Default()
+}
+
+// LogRequests logs each request's method, path, status and duration at info
+// level. Response bodies, which hold the phrases, are never logged.
+func LogRequests(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		h.ServeHTTP(sw, r)
+		logger().Info("request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", sw.status,
+			"remote", r.RemoteAddr,
+			"duration", time.Since(start))
+	})
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 Timothy Ham
+package web
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/timothyham/dicewords"
+)
+
+// latencyBuckets are the upper bounds, in seconds, of the generation
+// latency histogram.
+var latencyBuckets = []float64{0.0001, 0.00025, 0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1}
+
+// Metrics counts what the server does, for /metrics. It only ever records
+// labels and timings, never the phrases.
+type Metrics struct {
+	mu          sync.Mutex
+	requests    map[[2]string]uint64 // by format and dictionary
+	errors      map[int]uint64       // by status code
+	rateLimited uint64
+	latency     []uint64 // cumulative, one per bucket
+	latencySum  float64
+	latencyN    uint64
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{
+		requests: map[[2]string]uint64{},
+		errors:   map[int]uint64{},
+		latency:  make([]uint64, len(latencyBuckets)),
+	}
+}
+
+func (m *Metrics) request(format string, dict dicewords.Dictionary, d time.Duration) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[[2]string{format, dict.String()}]++
+	secs := d.Seconds()
+	for i, le := range latencyBuckets {
+		if secs <= le {
+			m.latency[i]++
+		}
+	}
+	m.latencySum += secs
+	m.latencyN++
+}
+
+func (m *Metrics) error(code int) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	m.errors[code]++
+	m.mu.Unlock()
+}
+
+func (m *Metrics) limited() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	m.rateLimited++
+	m.mu.Unlock()
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+	b.WriteString("# HELP dicewords_requests_total Passphrase requests answered, by format and dictionary.\n")
+	b.WriteString("# TYPE dicewords_requests_total counter\n")
+	keys := make([][2]string, 0, len(m.requests))
+	for k := range m.requests {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i][0] < keys[j][0] || keys[i][0] == keys[j][0] && keys[i][1] < keys[j][1]
+	})
+	for _, k := range keys {
+		fmt.Fprintf(&b, "dicewords_requests_total{format=%q,dict=%q} %d\n", k[0], k[1], m.requests[k])
+	}
+
+	b.WriteString("# HELP dicewords_errors_total Passphrase requests refused, by status code.\n")
+	b.WriteString("# TYPE dicewords_errors_total counter\n")
+	codes := make([]int, 0, len(m.errors))
+	for code := range m.errors {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	for _, code := range codes {
+		fmt.Fprintf(&b, "dicewords_errors_total{code=\"%d\"} %d\n", code, m.errors[code])
+	}
+
+	b.WriteString("# HELP dicewords_rate_limited_total Requests rejected by the rate limiter.\n")
+	b.WriteString("# TYPE dicewords_rate_limited_total counter\n")
+	fmt.Fprintf(&b, "dicewords_rate_limited_total %d\n", m.rateLimited)
+
+	b.WriteString("# HELP dicewords_generation_seconds Time spent generating the passphrases for a request.\n")
+	b.WriteString("# TYPE dicewords_generation_seconds histogram\n")
+	for i, le := range latencyBuckets {
+		fmt.Fprintf(&b, "dicewords_generation_seconds_bucket{le=\"%g\"} %d\n", le, m.latency[i])
+	}
+	fmt.Fprintf(&b, "dicewords_generation_seconds_bucket{le=\"+Inf\"} %d\n", m.latencyN)
+	fmt.Fprintf(&b, "dicewords_generation_seconds_sum %g\n", m.latencySum)
+	fmt.Fprintf(&b, "dicewords_generation_seconds_count %d\n", m.latencyN)
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// NewMux serves generate at / along with /healthz, /readyz and, if m is not
+// nil, /metrics. /readyz fails while the entropy source self-test fails.
+func NewMux(generate http.Handler, m *Metrics) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("/", generate)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok\n")
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if err := dicewords.EntropySelfTest(); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		io.WriteString(w, "ok\n")
+	})
+	if m != nil {
+		mux.Handle("/metrics", m)
+	}
+	return mux
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 Timothy Ham
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/timothyham/dicewords"
+)
+
+func TestMetrics(t *testing.T) {
+	m := NewMetrics()
+	h := NewHandler(dicewords.MakeConfig())
+	h.Metrics = m
+	limiter := NewRateLimiter(0.001, 3)
+	limiter.Metrics = m
+	var logBuf bytes.Buffer
+	old := dicewords.Logger
+	dicewords.Logger = dicewords.NewLogger(&logBuf, slog.LevelDebug)
+	defer func() { dicewords.Logger = old }()
+	mux := LogRequests(NewMux(limiter.Wrap(h), m))
+
+	get := func(url, accept string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest("GET", url, nil)
+		if accept != "" {
+			r.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w
+	}
+
+	w := get("/", "application/json")
+	var page Page
+	json.Unmarshal(w.Body.Bytes(), &page)
+	get("/?dict=short2", "")
+	get("/?format=xml", "")
+	get("/", "")
+
+	for _, path := range []string{"/healthz", "/readyz"} {
+		if w := get(path, ""); w.Code != http.StatusOK {
+			t.Errorf("%s: expected 200, got %d", path, w.Code)
+		}
+	}
+
+	if n := strings.Count(logBuf.String(), "msg=request"); n != 6 {
+		t.Errorf("expected 6 requests logged, got %d:\n%s", n, logBuf.String())
+	}
+
+	w = get("/metrics", "")
+	out := w.Body.String()
+	for _, want := range []string{
+		`dicewords_requests_total{format="json",dict="large"} 1`,
+		`dicewords_requests_total{format="text",dict="short2"} 1`,
+		`dicewords_errors_total{code="406"} 1`,
+		`dicewords_rate_limited_total 1`,
+		`dicewords_generation_seconds_bucket{le="+Inf"} 2`,
+		`dicewords_generation_seconds_count 2`,
+	} {
+		if !strings.Contains(out, want+"\n") {
+			t.Errorf("missing %q in\n%s", want, out)
+		}
+	}
+	for _, p := range append(page.Phrases, page.Apple...) {
+		if strings.Contains(out, p.Phrase) {
+			t.Errorf("metrics contain a phrase")
+		}
+		if strings.Contains(logBuf.String(), p.Phrase) {
+			t.Errorf("log contains a phrase")
+		}
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 Timothy Ham
+package web
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/timothyham/dicewords"
+)
+
+// Options are the serving settings shared by dicewords serve and
+// dicewords.cgi, normally set from command line flags by AddFlags.
+type Options struct {
+	HTTPAddr, FCGIAddr, SCGIAddr string
+	SocketMode                   string
+	CertFile, KeyFile            string
+	SelfSigned                   bool
+
+	MaxPhrases, MaxWords int
+	Rate                 float64
+	Burst                int
+	TrustedProxies       string
+}
+
+// FlagsHelp describes the flags added by AddFlags, for help text.
+const FlagsHelp = `-http
+    Serve HTTP on the given address, e.g. :8080.
+-fcgi
+    Serve FastCGI on the given address.
+-scgi
+    Serve SCGI on the given address.
+    Addresses are host:port, unix:/path/to/socket for a Unix socket, or
+    systemd (or systemd:name) for a socket passed by systemd socket activation.
+-socket-mode
+    Permissions for a Unix socket, in octal, e.g. 0660.
+-cert, -key
+    Serve -http over HTTPS with this certificate and key. The files are
+    reloaded on SIGHUP or when they change.
+-self-signed
+    Serve -http over HTTPS with a generated certificate. For testing only.
+-max-phrases, -max-words
+    Limits on what a request may ask for with ?p=, ?w= and ?b=.
+    Defaults are 20 and 20.
+-rate, -burst
+    Requests per second, and at once, allowed per client when serving.
+    Over the limit a client gets 429 Too Many Requests. Defaults are 1 and 10.
+    -rate 0 turns the limit off. Not applied when running as CGI.
+-trusted-proxies
+    Comma separated networks, e.g. 10.0.0.0/8,::1, of proxies whose
+    X-Forwarded-For header names the client. A proxy connecting to -http
+    on a Unix socket is always trusted.
+
+When serving, /healthz, /readyz and /metrics (Prometheus format) are
+answered too. /readyz fails if the random source self-test fails.
+`
+
+func (o *Options) AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.HTTPAddr, "http", "", "Serve HTTP on this address")
+	fs.StringVar(&o.FCGIAddr, "fcgi", "", "Serve FastCGI on this address")
+	fs.StringVar(&o.SCGIAddr, "scgi", "", "Serve SCGI on this address")
+	fs.StringVar(&o.SocketMode, "socket-mode", "", "Permissions for a Unix socket, in octal")
+	fs.StringVar(&o.CertFile, "cert", "", "TLS certificate file for -http")
+	fs.StringVar(&o.KeyFile, "key", "", "TLS key file for -http")
+	fs.BoolVar(&o.SelfSigned, "self-signed", false, "Serve -http over TLS with a generated certificate, for testing")
+	fs.IntVar(&o.MaxPhrases, "max-phrases", 20, "Most phrases a request may ask for")
+	fs.IntVar(&o.MaxWords, "max-words", dicewords.MaxWords, "Most words per phrase a request may ask for")
+	fs.Float64Var(&o.Rate, "rate", 1, "Requests per second allowed per client when serving, 0 for no limit")
+	fs.IntVar(&o.Burst, "burst", 10, "Requests a client may make at once when serving")
+	fs.StringVar(&o.TrustedProxies, "trusted-proxies", "", "Comma separated proxy networks whose X-Forwarded-For is believed")
+}
+
+// Listen returns the mode and address to serve on. The address is empty if
+// none was given.
+func (o *Options) Listen() (Mode, string, error) {
+	mode, addr := HTTP, ""
+	for _, l := range []struct {
+		mode Mode
+		addr string
+	}{{HTTP, o.HTTPAddr}, {FastCGI, o.FCGIAddr}, {SCGI, o.SCGIAddr}} {
+		if l.addr == "" {
+			continue
+		}
+		if addr != "" {
+			return mode, "", errors.New("only one of -http, -fcgi and -scgi may be given")
+		}
+		mode, addr = l.mode, l.addr
+	}
+	return mode, addr, nil
+}
+
+// Handler returns the passphrase handler for config with the request limits
+// applied.
+func (o *Options) Handler(config dicewords.Config, verbose bool) *Handler {
+	h := NewHandler(config)
+	h.Verbose = verbose
+	h.MaxPhrases = o.MaxPhrases
+	h.MaxWords = o.MaxWords
+	return h
+}
+
+// Server returns a server for h with metrics, rate limiting and request
+// logging set up as the options say.
+func (o *Options) Server(h *Handler) (*Server, error) {
+	mode, addr, err := o.Listen()
+	if err != nil {
+		return nil, err
+	}
+	if addr == "" {
+		return nil, errors.New("one of -http, -fcgi or -scgi is needed")
+	}
+	if o.Rate < 0 {
+		return nil, errors.New("-rate must be at least 0")
+	}
+	if o.Burst < 1 {
+		return nil, errors.New("-burst must be at least 1")
+	}
+	if err := dicewords.VerifyLists(); err != nil {
+		return nil, err
+	}
+	if err := h.checkLimits(h.Config); err != nil {
+		// every request would fail
+		return nil, fmt.Errorf("the default phrases are over -max-phrases or -max-words: %v", err)
+	}
+
+	metrics := NewMetrics()
+	h.Metrics = metrics
+	var generate http.Handler = h
+	if o.Rate > 0 {
+		limiter := NewRateLimiter(o.Rate, o.Burst)
+		limiter.Metrics = metrics
+		if limiter.TrustedProxies, err = ParseCIDRs(o.TrustedProxies); err != nil {
+			return nil, errors.New("bad -trusted-proxies: " + err.Error())
+		}
+		generate = limiter.Wrap(generate)
+	}
+
+	server := &Server{
+		Addr:       addr,
+		Mode:       mode,
+		Handler:    LogRequests(NewMux(generate, metrics)),
+		CertFile:   o.CertFile,
+		KeyFile:    o.KeyFile,
+		SelfSigned: o.SelfSigned,
+	}
+	if o.SocketMode != "" {
+		perm, err := strconv.ParseUint(o.SocketMode, 8, 32)
+		if err != nil || perm > 0777 {
+			return nil, errors.New("bad -socket-mode " + strconv.Quote(o.SocketMode))
+		}
+		server.SocketMode = os.FileMode(perm)
+	}
+	return server, nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 Timothy Ham
+package web
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/timothyham/dicewords"
+)
+
+func TestOptionsServer(t *testing.T) {
+	tests := []struct {
+		args    []string
+		words   int
+		wantErr bool
+	}{
+		{[]string{"-http", ":0"}, 0, false},
+		{[]string{"-http", ":0", "-rate", "0", "-burst", "0"}, 0, true},
+		{[]string{"-http", ":0", "-burst", "0"}, 0, true},
+		{[]string{"-http", ":0", "-burst", "-1"}, 0, true},
+		{[]string{"-http", ":0", "-rate", "-1"}, 0, true},
+		{[]string{"-http", ":0", "-max-phrases", "4"}, 0, true},
+		{[]string{"-http", ":0", "-max-words", "4"}, 0, true},
+		{[]string{"-http", ":0", "-max-words", "5"}, 5, false},
+		{[]string{"-http", ":0", "-max-words", "5"}, 6, true},
+		{[]string{}, 0, true},
+	}
+	for _, test := range tests {
+		var o Options
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		o.AddFlags(fs)
+		if err := fs.Parse(test.args); err != nil {
+			t.Fatal(err)
+		}
+		conf := dicewords.MakeConfig()
+		if test.words != 0 {
+			conf.NumWords = test.words
+		}
+		_, err := o.Server(o.Handler(conf, false))
+		if (err != nil) != test.wantErr {
+			t.Errorf("%v, %d words: unexpected error %v", test.args, test.words, err)
+		}
+	}
+}
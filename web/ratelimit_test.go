@@ -0,0 +1,132 @@
+// Copyright 2026 Timothy Ham
+package web
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterAllow(t *testing.T) {
+	l := NewRateLimiter(2, 3)
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := l.Allow("a", now); !ok {
+			t.Errorf("request %d: expected to be allowed", i)
+		}
+	}
+	ok, wait := l.Allow("a", now)
+	if ok {
+		t.Errorf("expected to be limited")
+	}
+	if wait != 500*time.Millisecond {
+		t.Errorf("expected 500ms, got %v", wait)
+	}
+	if ok, _ := l.Allow("b", now); !ok {
+		t.Errorf("other clients should not be limited")
+	}
+	if ok, _ := l.Allow("a", now.Add(500*time.Millisecond)); !ok {
+		t.Errorf("expected a token after 500ms")
+	}
+
+	l.Allow("a", now.Add(time.Hour))
+	if len(l.buckets) != 1 {
+		t.Errorf("expected idle buckets to be swept, have %d", len(l.buckets))
+	}
+}
+
+func TestRateLimiterClientIP(t *testing.T) {
+	l := NewRateLimiter(1, 1)
+	var err error
+	l.TrustedProxies, err = ParseCIDRs("10.0.0.0/8, 192.168.1.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		remote, forwarded, want string
+	}{
+		{"203.0.113.5:1234", "", "203.0.113.5"},
+		{"203.0.113.5:1234", "198.51.100.7", "203.0.113.5"},
+		{"10.1.2.3:80", "198.51.100.7", "198.51.100.7"},
+		{"10.1.2.3:80", "1.1.1.1, 198.51.100.7, 192.168.1.1", "198.51.100.7"},
+		{"10.1.2.3:80", "", "10.1.2.3"},
+		{"10.1.2.3:80", "garbage", "10.1.2.3"},
+		// HTTP on a Unix socket
+		{"@", "198.51.100.7", "198.51.100.7"},
+		{"", "1.1.1.1, 198.51.100.7", "198.51.100.7"},
+		{"@", "", "@"},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = test.remote
+		if test.forwarded != "" {
+			r.Header.Set("X-Forwarded-For", test.forwarded)
+		}
+		if got := l.ClientIP(r); got != test.want {
+			t.Errorf("%s %q: expected %s, got %s", test.remote, test.forwarded, test.want, got)
+		}
+	}
+
+	if _, err := ParseCIDRs("10.0.0.0/8,nonsense"); err == nil {
+		t.Errorf("expected error, got none")
+	}
+}
+
+func TestRateLimiterWrap(t *testing.T) {
+	l := NewRateLimiter(0.1, 1)
+	h := l.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected 429, got %d", w.Code)
+	}
+	if ra := w.Header().Get("Retry-After"); ra != "10" {
+		t.Errorf("expected Retry-After 10, got %q", ra)
+	}
+}
+
+func TestRateLimiterUnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dicewords.sock")
+	ln, err := Listen("unix:"+path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	l := NewRateLimiter(0.1, 1)
+	s := &Server{Mode: HTTP, Handler: l.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, l.ClientIP(r))
+	}))}
+	go s.Serve(ln)
+
+	client := http.Client{Transport: &http.Transport{
+		Dial: func(network, addr string) (net.Conn, error) {
+			return net.Dial("unix", path)
+		},
+	}}
+	// each client behind the proxy has its own bucket
+	for _, ip := range []string{"198.51.100.7", "198.51.100.8"} {
+		r, _ := http.NewRequest("GET", "http://dicewords/", nil)
+		r.Header.Set("X-Forwarded-For", ip)
+		resp, err := client.Do(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || string(body) != ip {
+			t.Errorf("%s: unexpected %d %q", ip, resp.StatusCode, body)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Timothy Ham
+package web
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/cgi"
+	"strconv"
+	"strings"
+)
+
+// maxSCGIHeader bounds the netstring holding the request headers.
+const maxSCGIHeader = 64 << 10
+
+// ServeSCGI accepts SCGI connections on l and answers each request with h.
+// See https://python.ca/scgi/protocol.txt for the protocol.
+func ServeSCGI(l net.Listener, h http.Handler) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go serveSCGIConn(conn, h)
+	}
+}
+
+func serveSCGIConn(conn net.Conn, h http.Handler) {
+	defer conn.Close()
+
+	br := bufio.NewReader(conn)
+	bw := bufio.NewWriter(conn)
+	defer bw.Flush()
+
+	req, err := readSCGIRequest(br)
+	if err != nil {
+		fmt.Fprintf(bw, "Status: 400 Bad Request\r\nContent-Type: text/plain\r\n\r\n%v\n", err)
+		return
+	}
+	w := &scgiResponse{w: bw, header: http.Header{}}
+	h.ServeHTTP(w, req)
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func readSCGIRequest(br *bufio.Reader) (*http.Request, error) {
+	lenStr, err := br.ReadString(':')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(lenStr, ":"))
+	if err != nil || n < 0 || n > maxSCGIHeader {
+		return nil, errors.New("bad SCGI netstring length")
+	}
+	raw := make([]byte, n+1)
+	if _, err := io.ReadFull(br, raw); err != nil {
+		return nil, err
+	}
+	if raw[n] != ',' {
+		return nil, errors.New("SCGI netstring not terminated by ','")
+	}
+
+	fields := bytes.Split(raw[:n], []byte{0})
+	// headers end with a NUL, leaving an empty last field
+	if len(fields)%2 != 1 {
+		return nil, errors.New("odd number of SCGI header fields")
+	}
+	params := map[string]string{}
+	for i := 0; i+1 < len(fields); i += 2 {
+		params[string(fields[i])] = string(fields[i+1])
+	}
+	if params["SCGI"] != "1" {
+		return nil, errors.New("missing SCGI header")
+	}
+	contentLength, err := strconv.ParseInt(params["CONTENT_LENGTH"], 10, 64)
+	if err != nil || contentLength < 0 {
+		return nil, errors.New("bad CONTENT_LENGTH")
+	}
+
+	req, err := cgi.RequestFromMap(params)
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(io.LimitReader(br, contentLength))
+	return req, nil
+}
+
+// scgiResponse writes a CGI style response: a Status line, the headers and
+// then the body.
+type scgiResponse struct {
+	w           *bufio.Writer
+	header      http.Header
+	wroteHeader bool
+}
+
+func (r *scgiResponse) Header() http.Header {
+	return r.header
+}
+
+func (r *scgiResponse) WriteHeader(code int) {
+	if r.wroteHeader {
+		return
+	}
+	r.wroteHeader = true
+	if r.header.Get("Content-Type") == "" {
+		r.header.Set("Content-Type", "text/html; charset=utf-8")
+	}
+	fmt.Fprintf(r.w, "Status: %d %s\r\n", code, http.StatusText(code))
+	r.header.Write(r.w)
+	r.w.WriteString("\r\n")
+}
+
+func (r *scgiResponse) Write(p []byte) (int, error) {
+	if !r.wroteHeader {
+		r.WriteHeader(http.StatusOK)
+	}
+	return r.w.Write(p)
+}
+
+func (r *scgiResponse) Flush() {
+	if !r.wroteHeader {
+		r.WriteHeader(http.StatusOK)
+	}
+	r.w.Flush()
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Timothy Ham
+package web
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func scgiRequest(headers [][2]string) string {
+	var h strings.Builder
+	for _, kv := range headers {
+		h.WriteString(kv[0] + "\x00" + kv[1] + "\x00")
+	}
+	return fmt.Sprintf("%d:%s,", h.Len(), h.String())
+}
+
+func TestServeSCGI(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, "%s %s %s %s", r.Method, r.URL.Path, r.URL.Query().Get("format"), body)
+	})
+	go ServeSCGI(l, h)
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	req := scgiRequest([][2]string{
+		{"CONTENT_LENGTH", "5"},
+		{"SCGI", "1"},
+		{"REQUEST_METHOD", "POST"},
+		{"REQUEST_URI", "/words?format=json"},
+		{"SERVER_PROTOCOL", "HTTP/1.1"},
+	})
+	fmt.Fprint(conn, req+"hello")
+
+	resp, err := io.ReadAll(bufio.NewReader(conn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(resp)
+	if !strings.HasPrefix(got, "Status: 200 OK\r\n") {
+		t.Errorf("unexpected response %q", got)
+	}
+	if !strings.Contains(got, "Content-Type: text/plain\r\n") {
+		t.Errorf("unexpected response %q", got)
+	}
+	if !strings.HasSuffix(got, "\r\n\r\nPOST /words json hello") {
+		t.Errorf("unexpected response %q", got)
+	}
+}
+
+func TestReadSCGIRequestErrors(t *testing.T) {
+	bad := []string{
+		"x:,",
+		"3:abc;",
+		scgiRequest([][2]string{{"CONTENT_LENGTH", "0"}}),
+		scgiRequest([][2]string{{"CONTENT_LENGTH", "-1"}, {"SCGI", "1"}}),
+	}
+	for _, in := range bad {
+		if _, err := readSCGIRequest(bufio.NewReader(strings.NewReader(in))); err == nil {
+			t.Errorf("%q: expected error, got none", in)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2026 Timothy Ham
+package web
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/fcgi"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Mode is the protocol spoken on a listener.
+type Mode int
+
+const (
+	HTTP Mode = iota
+	FastCGI
+	SCGI
+)
+
+func (m Mode) String() string {
+	switch m {
+	case HTTP:
+		return "http"
+	case FastCGI:
+		return "fcgi"
+	case SCGI:
+		return "scgi"
+	}
+	return fmt.Sprintf("Mode(%d)", int(m))
+}
+
+// Server serves Handler on a single listener.
+type Server struct {
+	// Addr is host:port for TCP, unix:/path for a Unix domain socket, or
+	// systemd (or systemd:name) for a socket passed in by systemd.
+	Addr string
+	Mode Mode
+	// SocketMode, if not zero, is applied to a Unix domain socket file
+	// after it is created.
+	SocketMode os.FileMode
+	Handler    http.Handler
+
+	// CertFile and KeyFile turn on HTTPS in HTTP mode. The certificate is
+	// reloaded on SIGHUP or when the files change.
+	CertFile, KeyFile string
+	// SelfSigned turns on HTTPS with a generated certificate for localhost,
+	// for testing.
+	SelfSigned bool
+}
+
+// certCheckInterval is how often the certificate files are checked for
+// changes.
+const certCheckInterval = time.Minute
+
+// ListenAndServe opens s.Addr and serves s.Handler on it.
+func (s *Server) ListenAndServe() error {
+	l, err := Listen(s.Addr, s.SocketMode)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+	return s.Serve(l)
+}
+
+// Serve answers requests arriving on l using the protocol for s.Mode.
+func (s *Server) Serve(l net.Listener) error {
+	tlsConfig, stop, err := s.tlsConfig()
+	if err != nil {
+		return err
+	}
+	defer close(stop)
+	if tlsConfig != nil && s.Mode != HTTP {
+		return fmt.Errorf("TLS is only supported in http mode, not %v", s.Mode)
+	}
+
+	switch s.Mode {
+	case HTTP:
+		hs := &http.Server{
+			Handler:           s.Handler,
+			ReadHeaderTimeout: 10 * time.Second,
+			TLSConfig:         tlsConfig,
+		}
+		if tlsConfig != nil {
+			return hs.ServeTLS(l, "", "")
+		}
+		return hs.Serve(l)
+	case FastCGI:
+		return fcgi.Serve(l, s.Handler)
+	case SCGI:
+		return ServeSCGI(l, s.Handler)
+	}
+	return fmt.Errorf("unknown mode %v", s.Mode)
+}
+
+// tlsConfig returns the TLS configuration for s, or nil if s serves plain
+// text. Closing stop ends any certificate watcher it started.
+func (s *Server) tlsConfig() (*tls.Config, chan struct{}, error) {
+	stop := make(chan struct{})
+	switch {
+	case s.CertFile != "" || s.KeyFile != "":
+		if s.CertFile == "" || s.KeyFile == "" {
+			return nil, stop, errors.New("both a certificate and a key file are needed")
+		}
+		if s.SelfSigned {
+			return nil, stop, errors.New("a self-signed certificate can't be combined with certificate files")
+		}
+		reloader, err := NewCertReloader(s.CertFile, s.KeyFile)
+		if err != nil {
+			return nil, stop, err
+		}
+		go reloader.Watch(certCheckInterval, stop)
+		return TLSConfig(reloader.GetCertificate), stop, nil
+	case s.SelfSigned:
+		certPEM, keyPEM, err := GenerateSelfSigned([]string{"localhost", "127.0.0.1", "::1"})
+		if err != nil {
+			return nil, stop, err
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, stop, err
+		}
+		return TLSConfig(func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return &cert, nil
+		}), stop, nil
+	}
+	return nil, stop, nil
+}
+
+// Listen opens addr as described for Server.Addr. A stale socket file left by
+// a previous run is removed first, but not one another server is still
+// listening on. perm, if not zero, is the new socket's permissions from the
+// moment it is created.
+func Listen(addr string, perm os.FileMode) (net.Listener, error) {
+	if addr == "systemd" || strings.HasPrefix(addr, "systemd:") {
+		return systemdListener(strings.TrimPrefix(strings.TrimPrefix(addr, "systemd"), ":"))
+	}
+
+	path, ok := strings.CutPrefix(addr, "unix:")
+	if !ok {
+		return net.Listen("tcp", addr)
+	}
+	if fi, err := os.Stat(path); err == nil && fi.Mode()&os.ModeSocket != 0 {
+		if c, err := net.DialTimeout("unix", path, time.Second); err == nil {
+			c.Close()
+			return nil, fmt.Errorf("another server is listening on %s", path)
+		}
+		os.Remove(path)
+	}
+	if perm == 0 {
+		return net.Listen("unix", path)
+	}
+	restore := setUmask(^perm & 0777)
+	l, err := net.Listen("unix", path)
+	restore()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, perm); err != nil {
+		l.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// listenFdsStart is the first file descriptor systemd passes, after stdin,
+// stdout and stderr.
+const listenFdsStart = 3
+
+// systemdListener returns a listener inherited through systemd socket
+// activation, see sd_listen_fds(3). With a name, the socket is picked by its
+// FileDescriptorName= in LISTEN_FDNAMES; otherwise there must be exactly one.
+func systemdListener(name string) (net.Listener, error) {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, errors.New("no sockets passed by systemd: LISTEN_PID is not this process")
+	}
+	n, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || n < 1 {
+		return nil, errors.New("no sockets passed by systemd: bad LISTEN_FDS")
+	}
+	names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+
+	idx := -1
+	if name == "" {
+		if n != 1 {
+			return nil, fmt.Errorf("systemd passed %d sockets; pick one with systemd:name", n)
+		}
+		idx = 0
+	} else {
+		for i := 0; i < n && i < len(names); i++ {
+			if names[i] == name {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return nil, fmt.Errorf("systemd passed no socket named %q", name)
+		}
+	}
+
+	f := os.NewFile(uintptr(listenFdsStart+idx), "systemd:"+name)
+	defer f.Close()
+	l, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("systemd socket %d: %v", listenFdsStart+idx, err)
+	}
+	return l, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 Timothy Ham
+package web
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestListenUnix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dicewords.sock")
+
+	l, err := Listen("unix:"+path, 0660)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0660 {
+		t.Errorf("expected 0660, got %v", fi.Mode().Perm())
+	}
+
+	s := &Server{Mode: HTTP, Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	})}
+	go s.Serve(l)
+	defer l.Close()
+
+	client := http.Client{Transport: &http.Transport{
+		Dial: func(network, addr string) (net.Conn, error) {
+			return net.Dial("unix", path)
+		},
+	}}
+	resp, err := client.Get("http://dicewords/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "ok" {
+		t.Errorf("unexpected body %q", body)
+	}
+
+	// a live server's socket is left alone
+	if l2, err := Listen("unix:"+path, 0); err == nil {
+		l2.Close()
+		t.Fatal("expected error listening on a live socket")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("live socket removed: %v", err)
+	}
+}
+
+func TestListenStaleUnix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dicewords.sock")
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.(*net.UnixListener).SetUnlinkOnClose(false)
+	l.Close()
+
+	// a stale socket from an earlier run does not stop a new listener
+	l2, err := Listen("unix:"+path, 0600)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer l2.Close()
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi.Mode().Perm() != 0600 {
+		t.Errorf("expected 0600, got %v", fi.Mode().Perm())
+	}
+}
+
+func TestListenSystemd(t *testing.T) {
+	t.Setenv("LISTEN_PID", "1")
+	t.Setenv("LISTEN_FDS", "1")
+	if _, err := Listen("systemd", 0); err == nil {
+		t.Errorf("expected error for another process's sockets, got none")
+	}
+
+	t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()))
+	t.Setenv("LISTEN_FDS", "2")
+	t.Setenv("LISTEN_FDNAMES", "http:fcgi")
+	if _, err := Listen("systemd", 0); err == nil {
+		t.Errorf("expected error for ambiguous sockets, got none")
+	}
+	if _, err := Listen("systemd:scgi", 0); err == nil {
+		t.Errorf("expected error for missing name, got none")
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Timothy Ham
+package web
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeSelfSigned(t *testing.T, certFile, keyFile string) *x509.Certificate {
+	certPEM, keyPEM, err := GenerateSelfSigned([]string{"localhost", "127.0.0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(certFile, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+
+	first := writeSelfSigned(t, certFile, keyFile)
+	r, err := NewCertReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := r.GetCertificate(nil)
+	if cert.Leaf == nil || !cert.Leaf.Equal(first) {
+		t.Errorf("unexpected certificate")
+	}
+
+	// a broken file keeps the old certificate
+	os.WriteFile(certFile, []byte("garbage"), 0600)
+	if err := r.Reload(); err == nil {
+		t.Errorf("expected error, got none")
+	}
+	cert, _ = r.GetCertificate(nil)
+	if !cert.Leaf.Equal(first) {
+		t.Errorf("certificate changed after failed reload")
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go r.Watch(10*time.Millisecond, stop)
+
+	second := writeSelfSigned(t, certFile, keyFile)
+	future := time.Now().Add(time.Hour)
+	os.Chtimes(certFile, future, future)
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		cert, _ = r.GetCertificate(nil)
+		if cert.Leaf.Equal(second) {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("certificate was not reloaded")
+}
+
+func TestServeTLS(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	s := &Server{Mode: HTTP, SelfSigned: true, Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	})}
+	go s.Serve(l)
+
+	for _, test := range []struct {
+		max uint16
+		ok  bool
+	}{{tls.VersionTLS11, false}, {tls.VersionTLS12, true}, {tls.VersionTLS13, true}} {
+		client := http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: true,
+			MinVersion:         tls.VersionTLS10,
+			MaxVersion:         test.max,
+		}}}
+		resp, err := client.Get("https://" + l.Addr().String() + "/")
+		if test.ok && err != nil {
+			t.Errorf("version %x: unexpected error %v", test.max, err)
+		}
+		if !test.ok && err == nil {
+			t.Errorf("version %x: expected error, got none", test.max)
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+	}
+
+	fcgi := &Server{Mode: FastCGI, SelfSigned: true}
+	if err := fcgi.Serve(l); err == nil {
+		t.Errorf("expected error for TLS in fcgi mode, got none")
+	}
+}
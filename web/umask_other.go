@@ -0,0 +1,12 @@
+// Copyright 2026 Timothy Ham
+
+//go:build !unix
+
+package web
+
+import "os"
+
+// setUmask does nothing where there is no umask.
+func setUmask(mask os.FileMode) func() {
+	return func() {}
+}
@@ -0,0 +1,18 @@
+// Copyright 2026 Timothy Ham
+
+//go:build unix
+
+package web
+
+import (
+	"os"
+	"syscall"
+)
+
+// setUmask sets the process umask to mask until the returned function is
+// called. The umask is shared by every goroutine, so it is only changed
+// while the listener is made at startup.
+func setUmask(mask os.FileMode) func() {
+	old := syscall.Umask(int(mask))
+	return func() { syscall.Umask(old) }
+}